@@ -0,0 +1,96 @@
+package goIP
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// Private span struct storing the first and last IP of an address block
+type span struct {
+	first uint64
+	firstof uint64
+	last uint64
+	lastof uint64
+}
+
+// Private functions
+
+func cmp128(ip, ipof, ip2, ipof2 uint64) (int) {
+	if ipof < ipof2 {return -1}
+	if ipof > ipof2 {return 1}
+	if ip < ip2 {return -1}
+	if ip > ip2 {return 1}
+	return 0
+}
+
+func tz128(ip, ipof uint64) (int) {
+	if ip != 0 {return bits.TrailingZeros64(ip)}
+	return 64+bits.TrailingZeros64(ipof)
+}
+
+func log128(ip, ipof uint64) (int) {
+	if ipof != 0 {return 127-bits.LeadingZeros64(ipof)}
+	return 63-bits.LeadingZeros64(ip)
+}
+
+func compareSpans(a, b span) (int) {
+	if c := cmp128(a.first, a.firstof, b.first, b.firstof); c != 0 {return c}
+	return cmp128(a.last, a.lastof, b.last, b.lastof)
+}
+
+// Sort spans and merge any that overlap or are adjacent
+func mergeSpans(spans []span) ([]span) {
+	if len(spans) == 0 {return spans}
+	slices.SortFunc(spans, compareSpans)
+	merged := []span{spans[0]}
+	for _, s := range spans[1:] {
+		cur := &merged[len(merged)-1]
+		next, nextof, carry := add128(cur.last, cur.lastof, 1, 0)
+		if carry != 0 || cmp128(s.first, s.firstof, next, nextof) <= 0 {
+			if cmp128(s.last, s.lastof, cur.last, cur.lastof) > 0 {
+				cur.last, cur.lastof = s.last, s.lastof
+			}
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
+
+// Decompose span into the minimal list of CIDR blocks
+func spanPrefixes(s span, isv6 bool) ([]*Ipinfo) {
+	var prefixes []*Ipinfo
+	maxbits := maxPrefix(isv6)
+	ip, ipof := s.first, s.firstof
+	for {
+		size := maxbits
+		if tz := tz128(ip, ipof); tz < size {size = tz}
+		diff, diffof, _ := sub128(s.last, s.lastof, ip, ipof)
+		count, countof, carry := add128(diff, diffof, 1, 0)
+		if carry == 0 {
+			if l := log128(count, countof); l < size {size = l}
+		}
+		prefixes = append(prefixes, newIP(ip, ipof, maxbits-size, isv6))
+		_, _, _, rmask, rmaskof := parseMasks(maxbits-size, isv6)
+		limit, limitof := parseLimit(ip, ipof, rmask, rmaskof)
+		if limit == s.last && limitof == s.lastof {return prefixes}
+		ip, ipof, _ = add128(limit, limitof, 1, 0)
+	}
+}
+
+// Public functions
+
+// Return minimal sorted list of networks covering exactly the same address space, IPv4 before IPv6, zones being ignored and dropped
+func Aggregate(nets []*Ipinfo) ([]*Ipinfo) {
+	var v4, v6 []span
+	for _, n := range nets {
+		if n == nil {continue}
+		s := span{first: n.prefix, firstof: n.prefixof, last: n.limit, lastof: n.limitof}
+		if n.isv6 {v6 = append(v6, s)
+		} else {v4 = append(v4, s)}
+	}
+	var aggregated []*Ipinfo
+	for _, s := range mergeSpans(v4) {aggregated = append(aggregated, spanPrefixes(s, false)...)}
+	for _, s := range mergeSpans(v6) {aggregated = append(aggregated, spanPrefixes(s, true)...)}
+	return aggregated
+}
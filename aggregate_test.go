@@ -0,0 +1,105 @@
+package goIP
+
+import (
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Private functions
+
+func mustIP(t *testing.T, ip string) (*Ipinfo) {
+	t.Helper()
+	newip, err := NewIP(ip)
+	if err != nil {t.Fatalf("NewIP(%q): %v", ip, err)}
+	return newip
+}
+
+func mustIPs(t *testing.T, ips ...string) ([]*Ipinfo) {
+	t.Helper()
+	var nets []*Ipinfo
+	for _, ip := range ips {nets = append(nets, mustIP(t, ip))}
+	return nets
+}
+
+func netStrings(nets []*Ipinfo) ([]string) {
+	var strs []string
+	for _, n := range nets {strs = append(strs, n.String())}
+	return strs
+}
+
+// Return bool of every network small enough to expand into single IPs
+func smallNets(nets []*Ipinfo) (bool) {
+	for _, n := range nets {
+		if n.suffixlen > 12 {return false}
+	}
+	return true
+}
+
+// Return every IP of networks, for checking results at the address level
+func netAddrs(nets []*Ipinfo) (map[Ipaddr]bool) {
+	addrs := map[Ipaddr]bool{}
+	for _, n := range nets {
+		for ip := range n.Hosts() {addrs[ip.Ipaddr()] = true}
+	}
+	return addrs
+}
+
+// Tests
+
+func TestAggregate(t *testing.T) {
+	tests := []struct {
+		in []string
+		want []string
+	}{
+		{nil, nil},
+		{[]string{"10.0.0.0/25", "10.0.0.128/25"}, []string{"10.0.0.0/24"}},
+		{[]string{"10.0.0.64/26", "10.0.0.0/24"}, []string{"10.0.0.0/24"}},
+		{[]string{"10.0.0.0/26", "10.0.0.32/27", "10.0.0.64/27"}, []string{"10.0.0.0/26", "10.0.0.64/27"}},
+		{[]string{"10.0.0.1/32", "10.0.0.2/32"}, []string{"10.0.0.1/32", "10.0.0.2/32"}},
+		{[]string{"10.0.0.1/32", "10.0.0.2/31", "10.0.0.0/32"}, []string{"10.0.0.0/30"}},
+		{[]string{"10.0.0.5/30"}, []string{"10.0.0.4/30"}},
+		{[]string{"10.0.0.0/8", "0.0.0.0/0"}, []string{"0.0.0.0/0"}},
+		{[]string{"8000::/1", "::/1"}, []string{"::/0"}},
+		{[]string{"255.255.255.255/32", "255.255.255.254/32"}, []string{"255.255.255.254/31"}},
+		{[]string{"2001:db8::/33", "10.0.0.1/32", "2001:db8:8000::/33", "10.0.0.0/32"}, []string{"10.0.0.0/31", "2001:db8::/32"}},
+		{[]string{"2001:db8:0:1::/64", "2001:db8::/64"}, []string{"2001:db8::/63"}},
+		{[]string{"2001:db8::ffff:ffff:ffff:ffff/128", "2001:db8:0:1::/128"}, []string{"2001:db8::ffff:ffff:ffff:ffff/128", "2001:db8:0:1::/128"}},
+	}
+	for _, test := range tests {
+		in := mustIPs(t, test.in...)
+		got := Aggregate(append(in, nil))
+		if !slices.Equal(netStrings(got), test.want) {
+			t.Errorf("Aggregate(%v) = %v, want %v", test.in, netStrings(got), test.want)
+		}
+		if smallNets(in) && !maps.Equal(netAddrs(in), netAddrs(got)) {
+			t.Errorf("Aggregate(%v) covers different IPs than input", test.in)
+		}
+	}
+}
+
+func TestAggregateRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for round := 0; round < 200; round++ {
+		var in []*Ipinfo
+		for n := rng.Intn(12); n > 0; n-- {
+			in = append(in, newIP(0x0a000000|uint64(rng.Intn(256)), 0, 24+rng.Intn(9), false))
+		}
+		got := Aggregate(in)
+		if !maps.Equal(netAddrs(in), netAddrs(got)) {
+			t.Fatalf("Aggregate(%v) = %v, covering different IPs", netStrings(in), netStrings(got))
+		}
+		for l := 1; l < len(got); l++ {
+			prev, cur := got[l-1], got[l]
+			if cmp128(prev.limit, prev.limitof, cur.prefix, cur.prefixof) >= 0 {
+				t.Fatalf("Aggregate(%v) = %v, not sorted and disjoint", netStrings(in), netStrings(got))
+			}
+			if prev.prefixlen == cur.prefixlen && prev.prefixlen > 0 {
+				if parent, _ := prev.Supernet(prev.prefixlen-1); parent.Contains(cur) {
+					t.Fatalf("Aggregate(%v) = %v, siblings not merged", netStrings(in), netStrings(got))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package goIP
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// Private functions
+
+func add128(ip, ipof, n, nof uint64) (sum, sumof, carry uint64) {
+	sum, carry = bits.Add64(ip, n, 0)
+	sumof, carry = bits.Add64(ipof, nof, carry)
+	return
+}
+
+func sub128(ip, ipof, n, nof uint64) (diff, diffof, borrow uint64) {
+	diff, borrow = bits.Sub64(ip, n, 0)
+	diffof, borrow = bits.Sub64(ipof, nof, borrow)
+	return
+}
+
+// Public functions
+
+// Add offset of 2 uint64, lower and upper bits, to 2 uint64 of IP and return error on overflow
+func Ipadd(ip, ipof, n, nof uint64, isv6 bool) (uint64, uint64, error) {
+	sum, sumof, carry := add128(ip, ipof, n, nof)
+	if carry != 0 || (!isv6 && (sumof != 0 || sum > 0xffffffff)) {
+		return 0, 0, errors.New("IP overflows address space")
+	}
+	return sum, sumof, nil
+}
+
+// Subtract offset of 2 uint64, lower and upper bits, from 2 uint64 of IP and return error on underflow
+func Ipsub(ip, ipof, n, nof uint64, isv6 bool) (uint64, uint64, error) {
+	if !isv6 && (ipof != 0 || ip > 0xffffffff) {return 0, 0, errors.New("IP out of IPv4 address space")}
+	diff, diffof, borrow := sub128(ip, ipof, n, nof)
+	if borrow != 0 {return 0, 0, errors.New("IP underflows address space")}
+	return diff, diffof, nil
+}
+
+// Return new instance of Ipinfo offset forward by 2 uint64, lower and upper bits, keeping prefix length
+func (i Ipinfo) Add(n, nof uint64) (*Ipinfo, error) {
+	ip, ipof, err := Ipadd(i.ip, i.ipof, n, nof, i.isv6)
+	if err != nil {return nil, err}
+	return newIP(ip, ipof, i.prefixlen, i.isv6).withZone(i.zone), nil
+}
+
+// Return new instance of Ipinfo offset backward by 2 uint64, lower and upper bits, keeping prefix length
+func (i Ipinfo) Sub(n, nof uint64) (*Ipinfo, error) {
+	ip, ipof, err := Ipsub(i.ip, i.ipof, n, nof, i.isv6)
+	if err != nil {return nil, err}
+	return newIP(ip, ipof, i.prefixlen, i.isv6).withZone(i.zone), nil
+}
+
+// Return new instance of Ipinfo for the next IP
+func (i Ipinfo) Next() (*Ipinfo, error) {
+	return i.Add(1, 0)
+}
+
+// Return new instance of Ipinfo for the previous IP
+func (i Ipinfo) Prev() (*Ipinfo, error) {
+	return i.Sub(1, 0)
+}
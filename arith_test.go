@@ -0,0 +1,63 @@
+package goIP
+
+import (
+	"testing"
+)
+
+// Tests
+
+func TestNextPrev(t *testing.T) {
+	tests := []struct {
+		in string
+		next, prev string
+	}{
+		{"10.0.0.1/24", "10.0.0.2/24", "10.0.0.0/24"},
+		{"10.0.0.255/32", "10.0.1.0/32", "10.0.0.254/32"},
+		{"255.255.255.255/32", "", "255.255.255.254/32"},
+		{"0.0.0.0/32", "0.0.0.1/32", ""},
+		{"::ffff:ffff:ffff:ffff/128", "0:0:0:1::/128", "::ffff:ffff:ffff:fffe/128"},
+		{"0:0:0:1::/128", "::1:0:0:0:1/128", "::ffff:ffff:ffff:ffff/128"},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128", "", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/128"},
+		{"::/128", "::1/128", ""},
+		{"fe80::1%eth0/64", "fe80::2%eth0/64", "fe80::%eth0/64"},
+	}
+	for _, test := range tests {
+		ip := mustIP(t, test.in)
+		next, err := ip.Next()
+		if test.next == "" {
+			if err == nil {t.Errorf("%s.Next() = %s, want overflow", test.in, next)}
+		} else if err != nil || next.String() != test.next {t.Errorf("%s.Next() = %v, %v, want %s", test.in, next, err, test.next)}
+		prev, err := ip.Prev()
+		if test.prev == "" {
+			if err == nil {t.Errorf("%s.Prev() = %s, want underflow", test.in, prev)}
+		} else if err != nil || prev.String() != test.prev {t.Errorf("%s.Prev() = %v, %v, want %s", test.in, prev, err, test.prev)}
+	}
+}
+
+func TestAddSub(t *testing.T) {
+	tests := []struct {
+		in string
+		n, nof uint64
+		sum, diff string
+	}{
+		{"10.0.0.0/32", 256, 0, "10.0.1.0/32", "9.255.255.0/32"},
+		{"10.0.0.0/32", 0, 1, "", ""},
+		{"2001:db8::/128", 0, 1, "2001:db8:0:1::/128", "2001:db7:ffff:ffff::/128"},
+		{"2001:db8::1/128", 0xffffffffffffffff, 0, "2001:db8:0:1::/128", "2001:db7:ffff:ffff::2/128"},
+		{"2001:db8::/128", 1, 0x20010db800000000, "4002:1b70::1/128", ""},
+		{"ffff::/128", 0, 0x0001000000000000, "", "fffe::/128"},
+	}
+	for _, test := range tests {
+		ip := mustIP(t, test.in)
+		sum, err := ip.Add(test.n, test.nof)
+		if test.sum == "" {
+			if err == nil {t.Errorf("%s.Add(%d, %d) = %s, want overflow", test.in, test.n, test.nof, sum)}
+		} else if err != nil || sum.String() != test.sum {t.Errorf("%s.Add(%d, %d) = %v, %v, want %s", test.in, test.n, test.nof, sum, err, test.sum)}
+		diff, err := ip.Sub(test.n, test.nof)
+		if test.diff == "" {
+			if err == nil {t.Errorf("%s.Sub(%d, %d) = %s, want underflow", test.in, test.n, test.nof, diff)}
+		} else if err != nil || diff.String() != test.diff {t.Errorf("%s.Sub(%d, %d) = %v, %v, want %s", test.in, test.n, test.nof, diff, err, test.diff)}
+	}
+	if _, _, err := Ipsub(0, 1, 0, 0, false); err == nil {t.Error("Ipsub accepted IPv4 IP with upper bits set")}
+	if _, _, err := Ipadd(0xffffffff, 0, 0, 0, false); err != nil {t.Errorf("Ipadd of zero offset to 255.255.255.255: %v", err)}
+}
@@ -0,0 +1,97 @@
+package goIP
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Public Atonform type selecting an alternate IPv4 notation understood by inet_aton
+type Atonform int
+
+// Alternate IPv4 notations returned by Aton
+const (
+	Atonoctal Atonform = iota
+	Atonhex
+	Atondecimal
+	Atonoctalint
+	Atonhexint
+	Atontwopart
+	Atonthreepart
+)
+
+// Private functions
+
+func parseAtonpart(part string) (uint64, error) {
+	if strings.HasPrefix(part, "0x") || strings.HasPrefix(part, "0X") {return strconv.ParseUint(part[2:], 16, 32)}
+	if len(part) > 1 && part[0] == '0' {return strconv.ParseUint(part[1:], 8, 32)}
+	return strconv.ParseUint(part, 10, 32)
+}
+
+func parseAton(ip string) (pip uint64, err error) {
+	parts := strings.Split(ip, ".")
+	if len(parts) > 4 {return 0, parseErr(Componentip, 0, "IP formatted incorrectly", ErrFormat)}
+	offset := 0
+	for l, part := range parts {
+		value, err := parseAtonpart(part)
+		// Every part but the last is a single octet, the last filling the remaining octets
+		max := uint64(0xff)
+		if l == len(parts)-1 {max = 0xffffffff>>(8*l)}
+		if err != nil || value > max {
+			return 0, parseErr(Componentoctet, offset, ordinals[l]+" part malformed", ErrOctet)
+		}
+		if l == len(parts)-1 {pip = pip<<(8*(4-l)) | value
+		} else {pip = pip<<8 | value}
+		offset += len(part)+1
+	}
+	return pip, nil
+}
+
+func formatOctets(ip uint64, base int, prefix string) (string) {
+	var builder strings.Builder
+	for l := 3; l >= 0; l-- {
+		octet := ip>>(8*l) & 0xff
+		if octet != 0 || base != 8 {builder.WriteString(prefix)}
+		builder.WriteString(strconv.FormatUint(octet, base))
+		if l != 0 {builder.WriteString(".")}
+	}
+	return builder.String()
+}
+
+// Public functions
+
+// Initialize new instance of Ipinfo from IPv4 in any inet_aton notation, such as "0x7f.1", "0177.0.0.1", "127.1" or "2130706433"
+func ParseAton(ip string) (*Ipinfo, error) {
+	tokens := strings.Split(ip, "/")
+	if len(tokens) > 2 {
+		return nil, withInput(parseErr(Componentprefix, strings.LastIndex(ip, "/"), "Too many \"/\" in input", ErrPrefix), ip)
+	}
+	pip, err := parseAton(tokens[0])
+	if err != nil {return nil, withInput(err, ip)}
+	prefixlen := 0
+	if len(tokens) == 2 {
+		offset := len(tokens[0])+1
+		prefix64, err := strconv.ParseUint(tokens[1], 10, 32)
+		if err != nil {return nil, withInput(parseErr(Componentprefix, offset, "Prefix length malformed", ErrPrefix), ip)}
+		prefixlen = int(prefix64)
+		err = checkPrefix(prefixlen, false)
+		if err != nil {return nil, withInput(atOffset(err, offset), ip)}
+	}
+	return newIP(pip, 0, prefixlen, false), nil
+}
+
+// Return IPv4 string in given inet_aton notation
+func (i Ipinfo) Aton(form Atonform) (string, error) {
+	if i.isv6 {return "", errors.New("IPv6 cannot be written in inet_aton notation")}
+	switch form {
+	case Atonoctal: return formatOctets(i.ip, 8, "0"), nil
+	case Atonhex: return formatOctets(i.ip, 16, "0x"), nil
+	case Atondecimal: return strconv.FormatUint(i.ip, 10), nil
+	case Atonoctalint: return "0"+strconv.FormatUint(i.ip, 8), nil
+	case Atonhexint: return "0x"+strconv.FormatUint(i.ip, 16), nil
+	case Atontwopart: return strconv.FormatUint(i.ip>>24, 10)+"."+strconv.FormatUint(i.ip&0xffffff, 10), nil
+	case Atonthreepart:
+		return strconv.FormatUint(i.ip>>24, 10)+"."+strconv.FormatUint(i.ip>>16 & 0xff, 10)+"."+strconv.FormatUint(i.ip&0xffff, 10), nil
+	}
+	return "", errors.New("Unknown inet_aton notation")
+}
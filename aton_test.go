@@ -0,0 +1,74 @@
+package goIP
+
+import (
+	"testing"
+)
+
+// Tests
+
+func TestParseAton(t *testing.T) {
+	tests := []struct {
+		in string
+		want string
+	}{
+		{"0x7f.1", "127.0.0.1/0"},
+		{"0177.0.0.1", "127.0.0.1/0"},
+		{"127.1", "127.0.0.1/0"},
+		{"127.0.1", "127.0.0.1/0"},
+		{"2130706433", "127.0.0.1/0"},
+		{"0x7f000001", "127.0.0.1/0"},
+		{"017700000001", "127.0.0.1/0"},
+		{"4294967295", "255.255.255.255/0"},
+		{"1.16777215", "1.255.255.255/0"},
+		{"1.2.65535", "1.2.255.255/0"},
+		{"0", "0.0.0.0/0"},
+		{"0X0A.0.0.1/8", "10.0.0.1/8"},
+		{"10.0.0.1/32", "10.0.0.1/32"},
+		{"4294967296", ""},
+		// The last part fills the remaining octets, so 256 is in range after a single octet
+		{"1.256", "1.0.1.0/0"},
+		{"1.16777216", ""},
+		{"1.2.65536", ""},
+		{"1.2.3.256", ""},
+		{"256.1", ""},
+		{"08", ""},
+		{"0x", ""},
+		{"0xg", ""},
+		{"1.2.3.4.5", ""},
+		{"1..2", ""},
+		{"", ""},
+		{"-1", ""},
+		{"1.2.3.4/33", ""},
+		{"1.2.3.4/8/8", ""},
+	}
+	for _, test := range tests {
+		got, err := ParseAton(test.in)
+		if test.want == "" {
+			if err == nil {t.Errorf("ParseAton(%q) = %s, want error", test.in, got)}
+			continue
+		}
+		if err != nil || got.String() != test.want {t.Errorf("ParseAton(%q) = %v, %v, want %s", test.in, got, err, test.want)}
+	}
+}
+
+func TestAtonRoundtrip(t *testing.T) {
+	forms := []Atonform{Atonoctal, Atonhex, Atondecimal, Atonoctalint, Atonhexint, Atontwopart, Atonthreepart}
+	for _, in := range []string{"0.0.0.0", "127.0.0.1", "10.1.2.3", "192.168.0.255", "255.255.255.255", "1.0.0.0"} {
+		ip := mustIP(t, in)
+		for _, form := range forms {
+			s, err := ip.Aton(form)
+			if err != nil {
+				t.Errorf("%s Aton(%d): %v", in, form, err)
+				continue
+			}
+			got, err := ParseAton(s)
+			if err != nil || got.Ip() != in {t.Errorf("ParseAton(%s Aton(%d) = %q) = %v, %v", in, form, s, got, err)}
+		}
+	}
+	want := map[Atonform]string{Atonoctal: "0177.0.0.01", Atonhex: "0x7f.0x0.0x0.0x1", Atondecimal: "2130706433",
+		Atonoctalint: "017700000001", Atonhexint: "0x7f000001", Atontwopart: "127.1", Atonthreepart: "127.0.1"}
+	for form, s := range want {
+		if got, _ := mustIP(t, "127.0.0.1").Aton(form); got != s {t.Errorf("127.0.0.1 Aton(%d) = %q, want %q", form, got, s)}
+	}
+	if _, err := mustIP(t, "::1").Aton(Atondecimal); err == nil {t.Error("Aton of IPv6 accepted")}
+}
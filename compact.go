@@ -0,0 +1,109 @@
+package goIP
+
+// Public Ipaddr struct, a compact comparable IP without zone suitable as a map key
+type Ipaddr struct {
+	ip uint64
+	ipof uint64
+	isv6 bool
+}
+
+// Public Ipprefix struct, a compact comparable network suitable as a map key
+type Ipprefix struct {
+	prefix uint64
+	prefixof uint64
+	prefixlen uint8
+	isv6 bool
+}
+
+// Public functions
+
+// Return compact Ipaddr of IP, dropping any zone
+func (i Ipinfo) Ipaddr() (Ipaddr) {
+	return Ipaddr{ip: i.ip, ipof: i.ipof, isv6: i.isv6}
+}
+
+// Return compact Ipprefix of network, dropping any zone
+func (i Ipinfo) Ipprefix() (Ipprefix) {
+	return Ipprefix{prefix: i.prefix, prefixof: i.prefixof, prefixlen: uint8(i.prefixlen), isv6: i.isv6}
+}
+
+// Return new instance of Ipinfo for IP with full length prefix
+func (a Ipaddr) Ipinfo() (*Ipinfo) {
+	return newIP(a.ip, a.ipof, maxPrefix(a.isv6), a.isv6)
+}
+
+// Return 2 uint64, lower and upper bits, of IP
+func (a Ipaddr) Ipint() (uint64, uint64) {
+	return a.ip, a.ipof
+}
+
+// Return IP string
+func (a Ipaddr) Ip() (string) {
+	return Iptostr(a.ip, a.ipof, a.isv6)
+}
+
+// Return bool of IPv6 or not
+func (a Ipaddr) Isv6() (bool) {
+	return a.isv6
+}
+
+// Return bool of IPv4 or not
+func (a Ipaddr) Isv4() (bool) {
+	return !a.isv6
+}
+
+// Return new instance of Ipinfo for network
+func (p Ipprefix) Ipinfo() (*Ipinfo) {
+	return newIP(p.prefix, p.prefixof, int(p.prefixlen), p.isv6)
+}
+
+// Return 2 uint64, lower and upper bits, of prefix
+func (p Ipprefix) Prefixint() (uint64, uint64) {
+	return p.prefix, p.prefixof
+}
+
+// Return prefix string
+func (p Ipprefix) Prefix() (string) {
+	return Iptostr(p.prefix, p.prefixof, p.isv6)
+}
+
+// Return prefix length
+func (p Ipprefix) Prefixlen() (int) {
+	return int(p.prefixlen)
+}
+
+// Return 2 uint64, lower and upper bits, of mask
+func (p Ipprefix) Maskint() (uint64, uint64) {
+	_, mask, maskof, _, _ := parseMasks(int(p.prefixlen), p.isv6)
+	return mask, maskof
+}
+
+// Return 2 uint64, lower and upper bits, of network upper bound
+func (p Ipprefix) Limitint() (uint64, uint64) {
+	_, _, _, rmask, rmaskof := parseMasks(int(p.prefixlen), p.isv6)
+	return parseLimit(p.prefix, p.prefixof, rmask, rmaskof)
+}
+
+// Return string of network upper bound
+func (p Ipprefix) Limit() (string) {
+	limit, limitof := p.Limitint()
+	return Iptostr(limit, limitof, p.isv6)
+}
+
+// Return bool of IPv6 or not
+func (p Ipprefix) Isv6() (bool) {
+	return p.isv6
+}
+
+// Return bool of IPv4 or not
+func (p Ipprefix) Isv4() (bool) {
+	return !p.isv6
+}
+
+// Return bool of IP within network or not
+func (p Ipprefix) Contains(a Ipaddr) (bool) {
+	if p.isv6 != a.isv6 {return false}
+	_, mask, maskof, _, _ := parseMasks(int(p.prefixlen), p.isv6)
+	ip, ipof := parsePrefix(a.ip, a.ipof, mask, maskof)
+	return ip == p.prefix && ipof == p.prefixof
+}
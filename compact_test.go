@@ -0,0 +1,50 @@
+package goIP
+
+import (
+	"strconv"
+	"testing"
+	"unsafe"
+)
+
+// Tests
+
+func TestIpprefixContains(t *testing.T) {
+	tests := []struct {
+		n, ip string
+		want bool
+	}{
+		{"10.0.0.0/24", "10.0.0.0", true},
+		{"10.0.0.7/24", "10.0.0.255", true},
+		{"10.0.0.0/24", "10.0.1.0", false},
+		{"0.0.0.0/0", "255.255.255.255", true},
+		{"10.0.0.1/32", "10.0.0.1", true},
+		{"10.0.0.1/32", "10.0.0.0", false},
+		{"10.0.0.0/8", "::a00:1", false},
+		{"::/0", "10.0.0.1", false},
+		{"2001:db8::/64", "2001:db8::ffff:ffff:ffff:ffff", true},
+		{"2001:db8::/64", "2001:db8:0:1::", false},
+		{"2001:db8::/65", "2001:db8:0:0:8000::", false},
+	}
+	for _, test := range tests {
+		p := mustIP(t, test.n).Ipprefix()
+		if got := p.Contains(mustIP(t, test.ip).Ipaddr()); got != test.want {t.Errorf("%s Contains(%s) = %v, want %v", test.n, test.ip, got, test.want)}
+	}
+}
+
+func TestCompactRoundtrip(t *testing.T) {
+	for _, in := range []string{"10.0.0.5/24", "0.0.0.0/0", "2001:db8::1/64", "::ffff:ffff:ffff:ffff/128", "fe80::1%eth0/64"} {
+		ip := mustIP(t, in)
+		p := ip.Ipprefix()
+		if p.Ipinfo().String() != ip.Prefix()+"/"+strconv.Itoa(ip.prefixlen) || p.Prefix() != ip.Prefix() || p.Limit() != ip.Limit() || p.Prefixlen() != ip.Prefixlen() {
+			t.Errorf("Ipprefix of %s = %s/%d to %s", in, p.Prefix(), p.Prefixlen(), p.Limit())
+		}
+		if mask, maskof := p.Maskint(); mask != ip.mask || maskof != ip.maskof {t.Errorf("Ipprefix of %s mask = %x %x", in, maskof, mask)}
+		a := ip.Ipaddr()
+		if a.Ipinfo().Prefixlen() != maxPrefix(ip.isv6) || a.Ip() != Iptostr(ip.ip, ip.ipof, ip.isv6) || a.Isv6() != ip.Isv6() {
+			t.Errorf("Ipaddr of %s = %s", in, a.Ipinfo())
+		}
+		if a != mustIP(t, a.Ip()).Ipaddr() {t.Errorf("Ipaddr of %s not equal after reparsing", in)}
+	}
+	if size := unsafe.Sizeof(Ipaddr{}); size > 24 {t.Errorf("Ipaddr is %d bytes", size)}
+	if size := unsafe.Sizeof(Ipprefix{}); size > 24 {t.Errorf("Ipprefix is %d bytes", size)}
+}
@@ -0,0 +1,52 @@
+package goIP
+
+import (
+	"slices"
+	"strings"
+)
+
+// Public functions
+
+// Compare IPs by IP version, IPv4 first, then by IP, then by zone, returning -1, 0 or 1 for use with slices.SortFunc
+func Compare(a, b *Ipinfo) (int) {
+	if a.isv6 != b.isv6 {
+		if a.isv6 {return 1}
+		return -1
+	}
+	if c := cmp128(a.ip, a.ipof, b.ip, b.ipof); c != 0 {return c}
+	return strings.Compare(a.zone, b.zone)
+}
+
+// Compare networks by IP version, IPv4 first, then by prefix, then by prefix length, then by zone, returning -1, 0 or 1
+func Comparenet(a, b *Ipinfo) (int) {
+	if a.isv6 != b.isv6 {
+		if a.isv6 {return 1}
+		return -1
+	}
+	if c := cmp128(a.prefix, a.prefixof, b.prefix, b.prefixof); c != 0 {return c}
+	if a.prefixlen < b.prefixlen {return -1}
+	if a.prefixlen > b.prefixlen {return 1}
+	return strings.Compare(a.zone, b.zone)
+}
+
+// Sort slice of IPs in place using Compare
+func Sort(ips []*Ipinfo) {
+	slices.SortStableFunc(ips, Compare)
+}
+
+// Sort slice of networks in place using Comparenet
+func Sortnets(nets []*Ipinfo) {
+	slices.SortStableFunc(nets, Comparenet)
+}
+
+// Sort slice of IPs in place and return it with duplicate IPs removed
+func Dedup(ips []*Ipinfo) ([]*Ipinfo) {
+	Sort(ips)
+	return slices.CompactFunc(ips, func(a, b *Ipinfo) (bool) {return Compare(a, b) == 0})
+}
+
+// Sort slice of networks in place and return it with duplicate networks removed
+func Dedupnets(nets []*Ipinfo) ([]*Ipinfo) {
+	Sortnets(nets)
+	return slices.CompactFunc(nets, func(a, b *Ipinfo) (bool) {return Comparenet(a, b) == 0})
+}
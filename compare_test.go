@@ -0,0 +1,47 @@
+package goIP
+
+import (
+	"slices"
+	"testing"
+)
+
+// Tests
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		ip, net int
+	}{
+		{"10.0.0.1/32", "10.0.0.1/32", 0, 0},
+		{"10.0.0.1/32", "10.0.0.2/32", -1, -1},
+		{"10.0.0.5/24", "10.0.0.9/24", -1, 0},
+		{"10.0.0.0/8", "10.0.0.0/16", 0, -1},
+		{"10.0.0.5/8", "10.0.0.0/16", 1, -1},
+		{"255.255.255.255/32", "::/0", -1, -1},
+		{"::ffff:a00:1/128", "10.0.0.1/32", 1, 1},
+		{"::ffff:ffff:ffff:ffff/128", "0:0:0:1::/128", -1, -1},
+		{"fe80::1/64", "fe80::1%eth0/64", -1, -1},
+		{"fe80::1%eth0/64", "fe80::1%eth1/64", -1, -1},
+	}
+	for _, test := range tests {
+		a, b := mustIP(t, test.a), mustIP(t, test.b)
+		if got := Compare(a, b); got != test.ip {t.Errorf("Compare(%s, %s) = %d, want %d", test.a, test.b, got, test.ip)}
+		if got := Compare(b, a); got != -test.ip {t.Errorf("Compare(%s, %s) = %d, want %d", test.b, test.a, got, -test.ip)}
+		if got := Comparenet(a, b); got != test.net {t.Errorf("Comparenet(%s, %s) = %d, want %d", test.a, test.b, got, test.net)}
+		if got := Comparenet(b, a); got != -test.net {t.Errorf("Comparenet(%s, %s) = %d, want %d", test.b, test.a, got, -test.net)}
+	}
+}
+
+func TestDedup(t *testing.T) {
+	ips := mustIPs(t, "2001:db8::1/128", "10.0.0.2/32", "10.0.0.1/24", "10.0.0.1/32", "fe80::1%eth0/128", "fe80::1/128", "2001:db8::1/64")
+	if got, want := ipStrings(Dedup(ips)), []string{"10.0.0.1", "10.0.0.2", "2001:db8::1", "fe80::1", "fe80::1%eth0"}; !slices.Equal(got, want) {
+		t.Errorf("Dedup() = %v, want %v", got, want)
+	}
+	nets := mustIPs(t, "10.0.0.9/24", "2001:db8::/32", "10.0.0.0/16", "10.0.0.1/24", "10.0.0.0/24", "fe80::%eth0/64", "fe80::/64", "0.0.0.0/0")
+	if got, want := netStrings(Dedupnets(nets)), []string{"0.0.0.0/0", "10.0.0.0/16", "10.0.0.9/24", "2001:db8::/32", "fe80::/64", "fe80::%eth0/64"}; !slices.Equal(got, want) {
+		t.Errorf("Dedupnets() = %v, want %v", got, want)
+	}
+	if got := Dedup(nil); len(got) != 0 {t.Errorf("Dedup(nil) = %v", got)}
+	sorted := mustIPs(t, "10.0.0.1/32", "10.0.0.3/32", "2001:db8::/128")
+	if n, found := slices.BinarySearchFunc(sorted, mustIP(t, "10.0.0.3/32"), Compare); !found || n != 1 {t.Errorf("BinarySearchFunc with Compare = %d, %v", n, found)}
+}
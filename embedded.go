@@ -0,0 +1,36 @@
+package goIP
+
+import (
+	"errors"
+)
+
+// Private functions
+
+// Return bool of well known /96 network embedding IPv4 in last 32 bits or not
+func isEmbedded(ip, ipof uint64) (bool) {
+	switch {
+	case ipof == 0 && ip>>32 == 0xffff: return true
+	case ipof == 0x0064ff9b00000000 && ip>>32 == 0: return true
+	// IPv4-compatible only when the seventh group is set, as inet_ntop does, keeping ::1 and ::ffff in hex
+	case ipof == 0 && ip>>32 == 0 && ip>>16 != 0: return true
+	}
+	return false
+}
+
+// Public functions
+
+// Return bool of IPv4-mapped IPv6 (::ffff:0:0/96) or not
+func (i Ipinfo) Ismapped() (bool) {
+	return i.isv6 && i.ipof == 0 && i.ip>>32 == 0xffff
+}
+
+// Return new instance of Ipinfo for IPv4 of IPv4-mapped IPv6, shortening prefix length by 96 down to 0
+func (i Ipinfo) Unmap() (*Ipinfo, error) {
+	if !i.Ismapped() {return nil, errors.New("IP not IPv4-mapped IPv6")}
+	return newIP(i.ip&0xffffffff, 0, max(i.prefixlen-96, 0), false), nil
+}
+
+// Return IP string, including IPv6 zone if any, using mixed notation with trailing dotted quad for IPv4-mapped, IPv4-compatible and IPv4/IPv6 translated IPv6
+func (i Ipinfo) Ipmixed() (string) {
+	return i.Ipstyle(Stylemixed)
+}
@@ -0,0 +1,75 @@
+package goIP
+
+import (
+	"testing"
+)
+
+// Tests
+
+func TestEmbeddedParse(t *testing.T) {
+	tests := []struct {
+		in string
+		ip, ipof uint64
+	}{
+		{"::ffff:1.2.3.4", 0xffff01020304, 0},
+		{"::ffff:1.2.3.4/96", 0xffff01020304, 0},
+		{"::1.2.3.4", 0x01020304, 0},
+		{"64:ff9b::192.0.2.1", 0xc0000201, 0x0064ff9b00000000},
+		{"1:2:3:4:5:6:1.2.3.4", 0x0005000601020304, 0x0001000200030004},
+		{"::ffff:255.255.255.255", 0xffffffffffff, 0},
+		{"fe80::1.2.3.4%eth0", 0x01020304, 0xfe80000000000000},
+	}
+	for _, test := range tests {
+		got := mustIP(t, test.in)
+		if got.ip != test.ip || got.ipof != test.ipof || !got.isv6 {t.Errorf("NewIP(%q) = %x %x", test.in, got.ipof, got.ip)}
+	}
+	for _, in := range []string{"::ffff:1.2.3", "::ffff:1.2.3.256", "1.2.3.4::", "::1.2.3.4:1", "1:2:3:4:5:6:7:1.2.3.4", "::ffff:01.2.3.4.5"} {
+		if got, err := NewIP(in); err == nil {t.Errorf("NewIP(%q) = %s, want error", in, got)}
+	}
+}
+
+func TestIpmixed(t *testing.T) {
+	tests := []struct {
+		in string
+		want string
+	}{
+		{"::ffff:1.2.3.4", "::ffff:1.2.3.4"},
+		{"::1.2.3.4", "::1.2.3.4"},
+		{"::0.1.0.0", "::0.1.0.0"},
+		{"64:ff9b::c000:201", "64:ff9b::192.0.2.1"},
+		{"::", "::"},
+		{"::1", "::1"},
+		{"::2", "::2"},
+		{"::ffff", "::ffff"},
+		{"::ffff:0:0", "::ffff:0.0.0.0"},
+		{"2001:db8::1.2.3.4", "2001:db8::102:304"},
+		{"fe80::ffff:1.2.3.4%eth0", "fe80::ffff:102:304%eth0"},
+		{"192.0.2.1", "192.0.2.1"},
+	}
+	for _, test := range tests {
+		if got := mustIP(t, test.in).Ipmixed(); got != test.want {t.Errorf("NewIP(%q).Ipmixed() = %q, want %q", test.in, got, test.want)}
+	}
+}
+
+func TestUnmap(t *testing.T) {
+	tests := []struct {
+		in string
+		want string
+	}{
+		{"::ffff:1.2.3.4/128", "1.2.3.4/32"},
+		{"::ffff:1.2.3.4/120", "1.2.3.4/24"},
+		{"::ffff:1.2.3.4/96", "1.2.3.4/0"},
+		{"::ffff:1.2.3.4/64", "1.2.3.4/0"},
+		{"::1.2.3.4/128", ""},
+		{"64:ff9b::1.2.3.4/128", ""},
+		{"1.2.3.4/32", ""},
+	}
+	for _, test := range tests {
+		ip := mustIP(t, test.in)
+		got, err := ip.Unmap()
+		if ip.Ismapped() != (test.want != "") {t.Errorf("%s Ismapped() = %v", test.in, ip.Ismapped())}
+		if test.want == "" {
+			if err == nil {t.Errorf("%s Unmap() = %s, want error", test.in, got)}
+		} else if err != nil || got.String() != test.want {t.Errorf("%s Unmap() = %v, %v, want %s", test.in, got, err, test.want)}
+	}
+}
@@ -0,0 +1,90 @@
+package goIP
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// Public Endpoint struct combining an IP with a port, comparable and suitable as a map key
+type Endpoint struct {
+	addr Ipaddr
+	port uint16
+	zone string
+}
+
+// Public functions
+
+// Initialize new instance of Endpoint from "<ipv4>:<port>" or "[<ipv6>[%<zone>]]:<port>" string
+func NewEndpoint(endpoint string) (*Endpoint, error) {
+	var (
+		host string
+		offset int
+		colon int
+	)
+	if strings.HasPrefix(endpoint, "[") {
+		end := strings.Index(endpoint, "]")
+		if end == -1 {return nil, withInput(parseErr(Componentip, 0, "Missing \"]\" in input", ErrFormat), endpoint)}
+		host, offset, colon = endpoint[1:end], 1, end+1
+		if colon == len(endpoint) || endpoint[colon] != ':' {
+			return nil, withInput(parseErr(Componentport, colon, "Port missing", ErrPort), endpoint)
+		}
+	} else {
+		colon = strings.LastIndex(endpoint, ":")
+		if colon == -1 {return nil, withInput(parseErr(Componentport, len(endpoint), "Port missing", ErrPort), endpoint)}
+		if strings.Contains(endpoint[:colon], ":") {
+			return nil, withInput(parseErr(Componentip, 0, "IPv6 endpoint must be in brackets", ErrFormat), endpoint)
+		}
+		host = endpoint[:colon]
+	}
+	if n := strings.Index(host, "/"); n != -1 {
+		return nil, withInput(parseErr(Componentprefix, offset+n, "Prefix length not allowed", ErrPrefix), endpoint)
+	}
+	ip, err := NewIP(host)
+	if err != nil {return nil, withInput(atOffset(err, offset), endpoint)}
+	if ip.isv6 != (offset == 1) {
+		return nil, withInput(parseErr(Componentip, 0, "Only IPv6 endpoints go in brackets", ErrFormat), endpoint)
+	}
+	port, err := strconv.ParseUint(endpoint[colon+1:], 10, 16)
+	if err != nil {return nil, withInput(parseErr(Componentport, colon+1, "Port malformed or out of range", ErrPort), endpoint)}
+	newendpoint := Endpoint{addr: ip.Ipaddr(), port: uint16(port), zone: ip.zone}
+	return &newendpoint, nil
+}
+
+// Initialize new instance of Endpoint from instance of Ipinfo and port
+func NewEndpointIP(ip *Ipinfo, port uint16) (*Endpoint) {
+	newendpoint := Endpoint{addr: ip.Ipaddr(), port: port, zone: ip.zone}
+	return &newendpoint
+}
+
+// Return new instance of Ipinfo for IP with full length prefix, including IPv6 zone if any
+func (e Endpoint) Ipinfo() (*Ipinfo) {
+	return e.addr.Ipinfo().withZone(e.zone)
+}
+
+// Return compact Ipaddr of IP, dropping any zone
+func (e Endpoint) Ipaddr() (Ipaddr) {
+	return e.addr
+}
+
+// Return IPv6 zone, or empty string if none
+func (e Endpoint) Zone() (string) {
+	return e.zone
+}
+
+// Return port
+func (e Endpoint) Port() (uint16) {
+	return e.port
+}
+
+// Return netip.AddrPort of endpoint
+func (e Endpoint) Netipaddrport() (netip.AddrPort) {
+	return netip.AddrPortFrom(e.Ipinfo().Netipaddr(), e.port)
+}
+
+// Return endpoint string, with IPv6 and any zone in brackets
+func (e Endpoint) String() (string) {
+	if e.zone != "" {return "["+e.addr.Ip()+"%"+e.zone+"]:"+strconv.FormatUint(uint64(e.port), 10)}
+	if e.addr.isv6 {return "["+e.addr.Ip()+"]:"+strconv.FormatUint(uint64(e.port), 10)}
+	return e.addr.Ip()+":"+strconv.FormatUint(uint64(e.port), 10)
+}
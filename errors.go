@@ -0,0 +1,74 @@
+package goIP
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Sentinel errors wrapped by ParseError, for use with errors.Is
+var (
+	ErrFormat = errors.New("IP formatted incorrectly")
+	ErrOctet = errors.New("IPv4 octet malformed")
+	ErrGroup = errors.New("IPv6 group malformed")
+	ErrPrefix = errors.New("Prefix length malformed")
+	ErrPrefixrange = errors.New("Prefix length out of range")
+	ErrHostbits = errors.New("Host bits set")
+	ErrVersion = errors.New("IP version not allowed")
+	ErrZone = errors.New("Zone malformed")
+	ErrPort = errors.New("Port malformed")
+)
+
+// Components of input reported by ParseError
+const (
+	Componentip = "ip"
+	Componentoctet = "octet"
+	Componentgroup = "group"
+	Componentprefix = "prefix"
+	Componentzone = "zone"
+	Componentport = "port"
+)
+
+// Public ParseError struct describing where and why parsing failed
+type ParseError struct {
+	Input string
+	Offset int
+	Component string
+	Reason string
+	Err error
+}
+
+// Ordinal names of octets and groups used in reasons
+var ordinals = [...]string{"First", "Second", "Third", "Fourth", "Fifth", "Sixth", "Seventh", "Eighth"}
+
+// Private functions
+
+func parseErr(component string, offset int, reason string, err error) (error) {
+	return &ParseError{Offset: offset, Component: component, Reason: reason, Err: err}
+}
+
+// Shift offset of ParseError by position of the parsed token within the input
+func atOffset(err error, offset int) (error) {
+	var perr *ParseError
+	if errors.As(err, &perr) {perr.Offset += offset}
+	return err
+}
+
+// Record full input on ParseError
+func withInput(err error, input string) (error) {
+	var perr *ParseError
+	if errors.As(err, &perr) {perr.Input = input}
+	return err
+}
+
+// Public functions
+
+// Return reason along with offset and input when known
+func (e *ParseError) Error() (string) {
+	if e.Input == "" {return e.Reason}
+	return e.Reason+" at offset "+strconv.Itoa(e.Offset)+" of \""+e.Input+"\""
+}
+
+// Return sentinel error for use with errors.Is
+func (e *ParseError) Unwrap() (error) {
+	return e.Err
+}
@@ -0,0 +1,135 @@
+package goIP
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Private functions
+
+// Check error is a ParseError of given offset, component and sentinel, recording the full input
+func checkParseError(t *testing.T, name, input string, err error, offset int, component string, sentinel error) {
+	t.Helper()
+	var perr *ParseError
+	if !errors.As(err, &perr) {
+		t.Errorf("%s(%q) error = %v, want ParseError", name, input, err)
+		return
+	}
+	if perr.Offset != offset || perr.Component != component || !errors.Is(err, sentinel) {
+		t.Errorf("%s(%q) = offset %d, component %s, %v, want offset %d, component %s, %v",
+			name, input, perr.Offset, perr.Component, perr.Err, offset, component, sentinel)
+	}
+	if perr.Input != input {t.Errorf("%s(%q) recorded input %q", name, input, perr.Input)}
+	if input != "" && !strings.Contains(err.Error(), "at offset "+strconv.Itoa(offset)+" of \""+input+"\"") {
+		t.Errorf("%s(%q) error text %q missing offset and input", name, input, err.Error())
+	}
+}
+
+// Tests
+
+func TestNewIPErrors(t *testing.T) {
+	tests := []struct {
+		in string
+		offset int
+		component string
+		sentinel error
+	}{
+		{"", 0, Componentip, ErrFormat},
+		{"1.2.3", 0, Componentip, ErrFormat},
+		{"1.2.3.4.5", 0, Componentip, ErrFormat},
+		{"1.2.x.4", 4, Componentoctet, ErrOctet},
+		{"256.1.1.1", 0, Componentoctet, ErrOctet},
+		{"1.2.3.", 6, Componentoctet, ErrOctet},
+		{"1.2.3.4/33", 8, Componentprefix, ErrPrefixrange},
+		{"1.2.3.4/x", 8, Componentprefix, ErrPrefix},
+		{"1.2.3.4/1/2", 9, Componentprefix, ErrPrefix},
+		{"12345::1", 0, Componentgroup, ErrGroup},
+		{"2001:db8::g", 10, Componentgroup, ErrGroup},
+		{"2001:db8:0:0:0:0:0:g", 19, Componentgroup, ErrGroup},
+		{"1::2::3", 4, Componentip, ErrFormat},
+		{"1:2:3:4:5:6:7:8:9", 0, Componentip, ErrFormat},
+		{"1:2:3:4:5:6:7", 0, Componentip, ErrFormat},
+		{"2001:db8::1/129", 12, Componentprefix, ErrPrefixrange},
+		// Embedded IPv4
+		{"::ffff:1.2.3.x", 13, Componentoctet, ErrOctet},
+		{"::ffff:1.300.3.4", 9, Componentoctet, ErrOctet},
+		{"::ffff:1.2.3.4.5", 7, Componentip, ErrFormat},
+		{"1:2:3:4:5:6:7:1.2.3.4", 0, Componentip, ErrFormat},
+		{"::ffff:1.2.3.4/200", 15, Componentprefix, ErrPrefixrange},
+		// Zones, offsets past the zone counting its length
+		{"fe80::1%", 7, Componentzone, ErrZone},
+		{"fe80::1%/64", 7, Componentzone, ErrZone},
+		{"fe80::1%eth0%1", 7, Componentzone, ErrZone},
+		{"1.2.3.4%eth0", 7, Componentzone, ErrZone},
+		{"fe80::g%eth0", 6, Componentgroup, ErrGroup},
+		{"fe80::1%eth0/129", 13, Componentprefix, ErrPrefixrange},
+		{"fe80::1%eth0/x", 13, Componentprefix, ErrPrefix},
+	}
+	for _, test := range tests {
+		_, err := NewIP(test.in)
+		checkParseError(t, "NewIP", test.in, err, test.offset, test.component, test.sentinel)
+	}
+}
+
+func TestNewEndpointErrors(t *testing.T) {
+	tests := []struct {
+		in string
+		offset int
+		component string
+		sentinel error
+	}{
+		{"1.2.3.4", 7, Componentport, ErrPort},
+		{"1.2.3.4:x", 8, Componentport, ErrPort},
+		{"1.2.3.4:65536", 8, Componentport, ErrPort},
+		{"1.2.x.4:80", 4, Componentoctet, ErrOctet},
+		{"1.2.3.4%eth0:80", 7, Componentzone, ErrZone},
+		{"[2001:db8::1]", 13, Componentport, ErrPort},
+		{"[2001:db8::1]:", 14, Componentport, ErrPort},
+		{"[2001:db8::1", 0, Componentip, ErrFormat},
+		{"[2001:db8::g]:80", 11, Componentgroup, ErrGroup},
+		{"[fe80::g%eth0]:80", 7, Componentgroup, ErrGroup},
+		{"[fe80::1%]:80", 8, Componentzone, ErrZone},
+		{"[fe80::1%eth0]:99999", 15, Componentport, ErrPort},
+		{"[2001:db8::1/64]:80", 12, Componentprefix, ErrPrefix},
+		{"[1.2.3.4]:80", 0, Componentip, ErrFormat},
+		{"2001:db8::1:80", 0, Componentip, ErrFormat},
+	}
+	for _, test := range tests {
+		_, err := NewEndpoint(test.in)
+		checkParseError(t, "NewEndpoint", test.in, err, test.offset, test.component, test.sentinel)
+	}
+}
+
+func TestParserErrors(t *testing.T) {
+	tests := []struct {
+		p Parser
+		in string
+		offset int
+		component string
+		sentinel error
+	}{
+		{Parser{V: 4}, "2001:db8::1", 0, Componentip, ErrVersion},
+		{Parser{V: 6}, "1.2.3.4", 0, Componentip, ErrVersion},
+		{Parser{Rejectzeros: true}, "1.02.3.4", 2, Componentoctet, ErrOctet},
+		{Parser{Rejectzeros: true}, "::ffff:1.2.03.4", 11, Componentoctet, ErrOctet},
+		{Parser{Rejectzeros: true}, "1.2.3.4/08", 8, Componentprefix, ErrPrefix},
+		{Parser{Prefix: Prefixrequired}, "1.2.3.4", 7, Componentprefix, ErrPrefix},
+		{Parser{Prefix: Prefixforbidden}, "1.2.3.4/8", 7, Componentprefix, ErrPrefix},
+		{Parser{Host: Hostreject}, "10.0.0.1/8", 0, Componentip, ErrHostbits},
+		{Parser{Aton: true}, "1.2.3.4/33", 8, Componentprefix, ErrPrefixrange},
+	}
+	for _, test := range tests {
+		_, err := test.p.Parse(test.in)
+		checkParseError(t, "Parse", test.in, err, test.offset, test.component, test.sentinel)
+	}
+}
+
+func TestParserVersion(t *testing.T) {
+	for _, v := range []int{-1, 1, 5, 46} {
+		if _, err := (Parser{V: v}).Parse("1.2.3.4"); err == nil || errors.Is(err, ErrVersion) {
+			t.Errorf("Parser{V: %d}.Parse error = %v, want invalid version error", v, err)
+		}
+	}
+}
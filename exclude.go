@@ -0,0 +1,46 @@
+package goIP
+
+// Private functions
+
+// Subtract sorted and merged spans from sorted and merged spans
+func subtractSpans(base, remove []span) ([]span) {
+	var remaining []span
+	j := 0
+	for _, b := range base {
+		ip, ipof := b.first, b.firstof
+		done := false
+		for ; j < len(remove); j++ {
+			r := remove[j]
+			if cmp128(r.last, r.lastof, ip, ipof) < 0 {continue}
+			if cmp128(r.first, r.firstof, b.last, b.lastof) > 0 {break}
+			if cmp128(r.first, r.firstof, ip, ipof) > 0 {
+				last, lastof, _ := sub128(r.first, r.firstof, 1, 0)
+				remaining = append(remaining, span{first: ip, firstof: ipof, last: last, lastof: lastof})
+			}
+			if cmp128(r.last, r.lastof, b.last, b.lastof) >= 0 {
+				done = true
+				break
+			}
+			ip, ipof, _ = add128(r.last, r.lastof, 1, 0)
+		}
+		if !done {remaining = append(remaining, span{first: ip, firstof: ipof, last: b.last, lastof: b.lastof})}
+	}
+	return remaining
+}
+
+// Public functions
+
+// Return minimal sorted list of networks remaining after removing given networks from network, zones being ignored and dropped
+func (i Ipinfo) Exclude(nets ...*Ipinfo) ([]*Ipinfo) {
+	var remove []span
+	for _, n := range nets {
+		if n == nil || n.isv6 != i.isv6 {continue}
+		remove = append(remove, span{first: n.prefix, firstof: n.prefixof, last: n.limit, lastof: n.limitof})
+	}
+	base := []span{{first: i.prefix, firstof: i.prefixof, last: i.limit, lastof: i.limitof}}
+	var remaining []*Ipinfo
+	for _, s := range subtractSpans(base, mergeSpans(remove)) {
+		remaining = append(remaining, spanPrefixes(s, i.isv6)...)
+	}
+	return remaining
+}
@@ -0,0 +1,54 @@
+package goIP
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+// Tests
+
+func TestExclude(t *testing.T) {
+	tests := []struct {
+		base string
+		remove []string
+		want []string
+	}{
+		{"10.0.0.0/24", nil, []string{"10.0.0.0/24"}},
+		{"10.0.0.0/24", []string{"10.0.0.0/24"}, nil},
+		{"10.0.0.0/24", []string{"10.0.0.0/8"}, nil},
+		{"10.0.0.0/24", []string{"10.0.1.0/24"}, []string{"10.0.0.0/24"}},
+		{"10.0.0.0/24", []string{"10.0.0.0/25"}, []string{"10.0.0.128/25"}},
+		{"10.0.0.0/24", []string{"10.0.0.64/26"}, []string{"10.0.0.0/26", "10.0.0.128/25"}},
+		{"10.0.0.0/30", []string{"10.0.0.1/32", "10.0.0.2/32"}, []string{"10.0.0.0/32", "10.0.0.3/32"}},
+		{"10.0.0.0/29", []string{"10.0.0.2/31", "10.0.0.3/32", "10.0.0.0/31"}, []string{"10.0.0.4/30"}},
+		{"10.0.0.0/24", []string{"2001:db8::/32"}, []string{"10.0.0.0/24"}},
+		{"0.0.0.0/0", []string{"128.0.0.0/1"}, []string{"0.0.0.0/1"}},
+		{"::/0", []string{"::/0"}, nil},
+		{"2001:db8::/63", []string{"2001:db8::/64"}, []string{"2001:db8:0:1::/64"}},
+		{"2001:db8::/63", []string{"2001:db8:0:0:8000::/65", "2001:db8:0:1::/65"}, []string{"2001:db8::/65", "2001:db8:0:1:8000::/65"}},
+		{"2001:db8::/63", []string{"2001:db8::/65", "2001:db8:0:1:8000::/65"}, []string{"2001:db8:0:0:8000::/65", "2001:db8:0:1::/65"}},
+		{"2001:db8::/126", []string{"2001:db8::1/128", "2001:db8::2/128"}, []string{"2001:db8::/128", "2001:db8::3/128"}},
+	}
+	for _, test := range tests {
+		base := mustIP(t, test.base)
+		remove := mustIPs(t, test.remove...)
+		got := base.Exclude(append(remove, nil)...)
+		if !slices.Equal(netStrings(got), test.want) {
+			t.Errorf("%s.Exclude(%v) = %v, want %v", test.base, test.remove, netStrings(got), test.want)
+		}
+	}
+}
+
+func TestExcludeAddresses(t *testing.T) {
+	base := mustIP(t, "10.0.0.0/26")
+	for first := 0; first < 64; first += 3 {
+		for prefixlen := 26; prefixlen <= 32; prefixlen++ {
+			remove := newIP(0x0a000000|uint64(first), 0, prefixlen, false)
+			want := netAddrs([]*Ipinfo{base})
+			for ip := range remove.Hosts() {delete(want, ip.Ipaddr())}
+			got := base.Exclude(remove)
+			if !maps.Equal(netAddrs(got), want) {t.Fatalf("%s.Exclude(%s) = %v, covering wrong IPs", base, remove, netStrings(got))}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package goIP
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Public Style type of flags selecting how IPv6 is written, IPv4 ignoring styles
+type Style uint8
+
+// Canonical RFC 5952 IPv6 style, the zero value
+const Stylecanonical Style = 0
+
+// IPv6 styles combined with "|"
+const (
+	Styleexpanded Style = 1<<iota
+	Stylepadded
+	Styleuppercase
+	Stylemixed
+)
+
+// Private functions
+
+func v6groups(v6, v6of uint64) ([8]uint64) {
+	return [8]uint64{
+		v6of>>48 & 0xffff, v6of>>32 & 0xffff, v6of>>16 & 0xffff, v6of & 0xffff,
+		v6>>48 & 0xffff, v6>>32 & 0xffff, v6>>16 & 0xffff, v6 & 0xffff}
+}
+
+// Write groups, compressing the longest run of 2 or more zero groups, leftmost on ties, unless expanded
+func formatGroups(groups []uint64, style Style) (string) {
+	start, length := -1, 1
+	if style&Styleexpanded == 0 {
+		for l := 0; l < len(groups); {
+			if groups[l] != 0 {
+				l++
+				continue
+			}
+			run := l
+			for run < len(groups) && groups[run] == 0 {run++}
+			if run-l > length {start, length = l, run-l}
+			l = run
+		}
+	}
+	var builder strings.Builder
+	for l := 0; l < len(groups); l++ {
+		if l == start {
+			builder.WriteString("::")
+			l += length-1
+			continue
+		}
+		if l != 0 && l != start+length {builder.WriteString(":")}
+		group := strconv.FormatUint(groups[l], 16)
+		if style&Stylepadded != 0 {group = strings.Repeat("0", 4-len(group))+group}
+		builder.WriteString(group)
+	}
+	if style&Styleuppercase != 0 {return strings.ToUpper(builder.String())}
+	return builder.String()
+}
+
+// Public functions
+
+// Convert 2 uint64, lower and upper bits, to string in given style
+func Iptostyle(ip, ipof uint64, isv6 bool, style Style) (string) {
+	if !isv6 {return v4tostr(ip)}
+	groups := v6groups(ip, ipof)
+	if style&Stylemixed == 0 || !isEmbedded(ip, ipof) {return formatGroups(groups[:], style)}
+	// Mixed notation writes the first 6 groups followed by a dotted quad
+	v6str := formatGroups(groups[:6], style)
+	if !strings.HasSuffix(v6str, "::") {v6str += ":"}
+	return v6str+v4tostr(ip&0xffffffff)
+}
+
+// Return IP string in given style, including IPv6 zone if any
+func (i Ipinfo) Ipstyle(style Style) (string) {
+	if i.zone != "" {return Iptostyle(i.ip, i.ipof, i.isv6, style)+"%"+i.zone}
+	return Iptostyle(i.ip, i.ipof, i.isv6, style)
+}
@@ -0,0 +1,92 @@
+package goIP
+
+import (
+	"math/rand"
+	"net/netip"
+	"testing"
+)
+
+// Tests
+
+func TestCanonicalFormat(t *testing.T) {
+	tests := []struct {
+		in string
+		want string
+	}{
+		{"::", "::"},
+		{"::1", "::1"},
+		{"1::", "1::"},
+		{"2001:db8:0:0:1:0:0:1", "2001:db8::1:0:0:1"},
+		{"2001:db8:0:0:0:5:0:0", "2001:db8::5:0:0"},
+		{"2001:0:0:1:0:0:0:1", "2001:0:0:1::1"},
+		{"1:0:2:3:4:5:6:7", "1:0:2:3:4:5:6:7"},
+		{"1:2:3:4:5:6:7:0", "1:2:3:4:5:6:7:0"},
+		{"0:1:2:3:4:5:6:7", "0:1:2:3:4:5:6:7"},
+		{"1:0:0:2:3:4:5:6", "1::2:3:4:5:6"},
+		{"0:0:1:0:0:0:0:0", "0:0:1::"},
+		{"2001:DB8:0000:0000:0000:0000:0000:00FF", "2001:db8::ff"},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+		{"::ffff:1.2.3.4", "::ffff:102:304"},
+	}
+	for _, test := range tests {
+		ip := mustIP(t, test.in)
+		if got := ip.Ip(); got != test.want {t.Errorf("NewIP(%q).Ip() = %q, want %q", test.in, got, test.want)}
+	}
+	if got := mustIP(t, "2001:db8::1:0:0:1/64").Prefix(); got != "2001:db8::" {t.Errorf("Prefix() = %q, want 2001:db8::", got)}
+	if got := mustIP(t, "::/64").Mask(); got != "ffff:ffff:ffff:ffff::" {t.Errorf("Mask() = %q, want ffff:ffff:ffff:ffff::", got)}
+}
+
+func TestCanonicalFormatRandom(t *testing.T) {
+	// Groups drawn mostly from zero so runs of every length and position occur
+	rng := rand.New(rand.NewSource(1))
+	for round := 0; round < 20000; round++ {
+		var b [16]byte
+		for l := 0; l < 16; l += 2 {
+			if rng.Intn(3) != 0 {continue}
+			b[l], b[l+1] = byte(rng.Intn(256)), byte(rng.Intn(256))
+		}
+		ip, err := From16(b, 128)
+		if err != nil {t.Fatal(err)}
+		if want := netip.AddrFrom16(b).StringExpanded(); ip.Ipstyle(Styleexpanded|Stylepadded) != want {
+			t.Fatalf("Ipstyle of %x = %q, want %q", b, ip.Ipstyle(Styleexpanded|Stylepadded), want)
+		}
+		// netip writes IPv4-mapped IPv6 in mixed notation, so compare the hex form through its bytes instead
+		want := netip.AddrFrom16(b)
+		if want.Is4In6() {continue}
+		if ip.Ip() != want.String() {t.Fatalf("Ip() of %x = %q, want %q", b, ip.Ip(), want.String())}
+	}
+}
+
+func TestStyles(t *testing.T) {
+	tests := []struct {
+		in string
+		style Style
+		want string
+	}{
+		{"2001:db8::ab", Stylecanonical, "2001:db8::ab"},
+		{"2001:db8::ab", Styleexpanded, "2001:db8:0:0:0:0:0:ab"},
+		{"2001:db8::ab", Stylepadded, "2001:0db8::00ab"},
+		{"2001:db8::ab", Styleexpanded|Stylepadded, "2001:0db8:0000:0000:0000:0000:0000:00ab"},
+		{"2001:db8::ab", Styleuppercase, "2001:DB8::AB"},
+		{"2001:db8::ab", Stylemixed, "2001:db8::ab"},
+		{"::ffff:1.2.3.4", Stylemixed, "::ffff:1.2.3.4"},
+		{"::1.2.3.4", Stylemixed, "::1.2.3.4"},
+		{"64:ff9b::1.2.3.4", Stylemixed, "64:ff9b::1.2.3.4"},
+		{"1:2:3:4:5:6:1.2.3.4", Stylemixed, "1:2:3:4:5:6:102:304"},
+		{"::ffff:1.2.3.4", Stylemixed|Styleexpanded, "0:0:0:0:0:ffff:1.2.3.4"},
+		{"::ffff:1.2.3.4", Stylemixed|Styleexpanded|Stylepadded|Styleuppercase, "0000:0000:0000:0000:0000:FFFF:1.2.3.4"},
+		{"fe80::1%eth0", Stylepadded, "fe80::0001%eth0"},
+		{"192.0.2.1", Styleexpanded|Stylepadded|Stylemixed, "192.0.2.1"},
+	}
+	for _, test := range tests {
+		if got := mustIP(t, test.in).Ipstyle(test.style); got != test.want {
+			t.Errorf("NewIP(%q).Ipstyle(%d) = %q, want %q", test.in, test.style, got, test.want)
+		}
+	}
+}
+
+func TestStyleValues(t *testing.T) {
+	if Stylecanonical != 0 || Styleexpanded != 1 || Stylepadded != 2 || Styleuppercase != 4 || Stylemixed != 8 {
+		t.Errorf("Style flags = %d, %d, %d, %d, %d, want 0, 1, 2, 4, 8", Stylecanonical, Styleexpanded, Stylepadded, Styleuppercase, Stylemixed)
+	}
+}
@@ -0,0 +1,64 @@
+package goIP
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Private functions
+
+// Return bit string of IP with "/" between network and host bits
+func (i Ipinfo) bitstr() (string) {
+	var builder strings.Builder
+	maxbits := maxPrefix(i.isv6)
+	for l := 0; l < maxbits; l++ {
+		if l == i.prefixlen {builder.WriteString("/")}
+		builder.WriteByte(byte('0'+bitAt(i.ip, i.ipof, l, i.isv6)))
+	}
+	if i.prefixlen == maxbits {builder.WriteString("/")}
+	return builder.String()
+}
+
+// Return report of every field, as printed by %+v
+func (i Ipinfo) report() (string) {
+	return "{ip:"+i.Ip()+
+		" prefix:"+i.Prefix()+
+		" limit:"+i.Limit()+
+		" mask:"+i.Mask()+
+		" rmask:"+i.Rmask()+
+		" prefixlen:"+strconv.Itoa(i.prefixlen)+
+		" suffixlen:"+strconv.Itoa(i.suffixlen)+
+		" v:"+strconv.Itoa(i.V())+"}"
+}
+
+// Public functions
+
+// Return network string in CIDR notation
+func (i Ipinfo) String() (string) {
+	return i.Ip()+"/"+strconv.Itoa(i.prefixlen)
+}
+
+// Implement fmt.Formatter, %s and %v printing CIDR notation, %+v every field, %d the IP as a decimal integer,
+// %x and %X the 2 uint64, upper then lower bits, of IP in hex, and %b the bits of IP with "/" between network and host bits
+func (i Ipinfo) Format(f fmt.State, verb rune) {
+	var s string
+	switch verb {
+	case 's', 'v':
+		if verb == 'v' && f.Flag('+') {s = i.report()
+		} else {s = i.String()}
+	case 'q': s = strconv.Quote(i.String())
+	case 'd': s = new(big.Int).Or(new(big.Int).Lsh(new(big.Int).SetUint64(i.ipof), 64), new(big.Int).SetUint64(i.ip)).String()
+	case 'x', 'X':
+		if i.isv6 {s = fmt.Sprintf("%016x%016x", i.ipof, i.ip)
+		} else {s = fmt.Sprintf("%08x", i.ip)}
+		if f.Flag('#') {s = "0x"+s}
+		if verb == 'X' {s = strings.ToUpper(s)}
+	case 'b': s = i.bitstr()
+	default:
+		fmt.Fprintf(f, "%%!%c(goIP.Ipinfo=%s)", verb, i.String())
+		return
+	}
+	fmt.Fprintf(f, fmt.FormatString(f, 's'), s)
+}
@@ -0,0 +1,39 @@
+package goIP
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Tests
+
+func TestFormat(t *testing.T) {
+	v4, v6 := mustIP(t, "10.0.0.1/30"), mustIP(t, "2001:db8::1/64")
+	tests := []struct {
+		format string
+		ip *Ipinfo
+		want string
+	}{
+		{"%s", v4, "10.0.0.1/30"},
+		{"%v", v6, "2001:db8::1/64"},
+		{"%q", v4, `"10.0.0.1/30"`},
+		{"%20s|", v4, "         10.0.0.1/30|"},
+		{"%-14s|", v4, "10.0.0.1/30   |"},
+		{"%d", v4, "167772161"},
+		{"%d", v6, "42540766411282592856903984951653826561"},
+		{"%x", v4, "0a000001"},
+		{"%#x", v4, "0x0a000001"},
+		{"%X", v6, "20010DB8000000000000000000000001"},
+		{"%#X", v6, "0X20010DB8000000000000000000000001"},
+		{"%b", v4, "000010100000000000000000000000/01"},
+		{"%b", mustIP(t, "255.255.255.255/32"), "11111111111111111111111111111111/"},
+		{"%b", mustIP(t, "0.0.0.0/0"), "/00000000000000000000000000000000"},
+		{"%+v", v4, "{ip:10.0.0.1 prefix:10.0.0.0 limit:10.0.0.3 mask:255.255.255.252 rmask:0.0.0.3 prefixlen:30 suffixlen:2 v:4}"},
+		{"%s", mustIP(t, "fe80::1%eth0/64"), "fe80::1%eth0/64"},
+		{"%z", v4, "%!z(goIP.Ipinfo=10.0.0.1/30)"},
+	}
+	for _, test := range tests {
+		if got := fmt.Sprintf(test.format, test.ip); got != test.want {t.Errorf("Sprintf(%q, %s) = %q, want %q", test.format, test.ip, got, test.want)}
+	}
+	if got := fmt.Sprint(*v4); got != "10.0.0.1/30" {t.Errorf("Sprint of value = %q", got)}
+}
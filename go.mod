@@ -1 +1,3 @@
 module github.com/ScriptTiger/goIP
+
+go 1.23
@@ -21,15 +21,28 @@ type Ipinfo struct {
 	prefixlen int
 	suffixlen int
 	isv6 bool
+	zone string
 }
 
 // Private functions
 
+// Split IPv6 zone from input, returning input without zone and offset of zone
+func splitZone(ip string) (string, string, int, error) {
+	n := strings.Index(ip, "%")
+	if n == -1 {return ip, "", -1, nil}
+	end := len(ip)
+	if slash := strings.Index(ip[n:], "/"); slash != -1 {end = n+slash}
+	zone := ip[n+1:end]
+	if zone == "" {return "", "", 0, parseErr(Componentzone, n, "Zone empty", ErrZone)}
+	if strings.Contains(zone, "%") {return "", "", 0, parseErr(Componentzone, n, "Zone formatted incorrectly", ErrZone)}
+	return ip[:n]+ip[end:], zone, n, nil
+}
+
 func ipv(ip string) (bool, error) {
 	count := strings.Count(ip, ":")
-	if count >= 2 && count <= 7 && !strings.Contains(ip, ".") {return true, nil}
+	if count >= 2 && count <= 7 {return true, nil}
 	if strings.Count(ip, ".") == 3 && !strings.Contains(ip, ":") {return false, nil}
-	return false, errors.New("IP formatted incorrectly")
+	return false, parseErr(Componentip, 0, "IP formatted incorrectly", ErrFormat)
 }
 
 func parse(ip string, isv6 bool) (pip, pipof uint64, prefix int, err error) {
@@ -38,10 +51,15 @@ func parse(ip string, isv6 bool) (pip, pipof uint64, prefix int, err error) {
 		tokens := strings.Split(ip, "/")
 		pip, pipof, err = parseIP(tokens[0], isv6)
 		if err != nil {return 0, 0, 0, err}
+		offset := len(tokens[0])+1
 		prefix64, err := strconv.ParseUint(tokens[1], 10, 32)
-		if err != nil {return 0, 0, 0, err}
+		if err != nil {return 0, 0, 0, parseErr(Componentprefix, offset, "Prefix length malformed", ErrPrefix)}
 		prefix = int(prefix64)
-	} else if count > 1 {return 0, 0, 0, errors.New("Too many \"/\" in input")
+		err = checkPrefix(prefix, isv6)
+		if err != nil {return 0, 0, 0, atOffset(err, offset)}
+	} else if count > 1 {
+		offset := strings.LastIndex(ip, "/")
+		return 0, 0, 0, parseErr(Componentprefix, offset, "Too many \"/\" in input", ErrPrefix)
 	} else {
 		pip, pipof, err = parseIP(ip, isv6)
 		if err != nil {return 0, 0, 0, err}
@@ -59,55 +77,63 @@ func parseIP(ip string, isv6 bool) (pip, pipof uint64, err error) {
 
 func parsev4(ip string) (pip uint64, err error) {
 	octets := strings.Split(ip, ".")
-	if len(octets) != 4 {return 0, errors.New("IP formatted incorrectly")}
-	octet1, err := strconv.ParseUint(octets[0], 10, 8)
-	if err != nil {return 0, errors.New("First octet malformed")}
-	octet2, err := strconv.ParseUint(octets[1], 10, 8)
-	if err != nil {return 0, errors.New("Second octet malformed")}
-	octet3, err := strconv.ParseUint(octets[2], 10, 8)
-	if err != nil {return 0, errors.New("Third octet malformed")}
-	octet4, err := strconv.ParseUint(octets[3], 10, 8)
-	if err != nil {return 0, errors.New("Fourth octet malformed")}
-	pip = octet1<<24 | octet2<<16 | octet3<<8 | octet4
+	if len(octets) != 4 {return 0, parseErr(Componentip, 0, "IP formatted incorrectly", ErrFormat)}
+	offset := 0
+	for l, octet := range octets {
+		value, err := strconv.ParseUint(octet, 10, 8)
+		if err != nil {return 0, parseErr(Componentoctet, offset, ordinals[l]+" octet malformed", ErrOctet)}
+		pip = pip<<8 | value
+		offset += len(octet)+1
+	}
 	return pip, nil
 }
 
+// Split colon separated groups, recording offset of each group
+func splitGroups(ip string, offset int) (groups []string, offsets []int) {
+	if ip == "" {return nil, nil}
+	for _, group := range strings.Split(ip, ":") {
+		groups = append(groups, group)
+		offsets = append(offsets, offset)
+		offset += len(group)+1
+	}
+	return groups, offsets
+}
+
 func parsev6(ip string) (pip, pipof uint64, err error) {
-	if strings.Count(ip, "::") > 1 {return 0, 0, errors.New("IP formatted incorrectly")}
-	var groups[] string
-	if strings.Contains(ip, "::") {
-		var zeros[] string
-		if strings.HasPrefix(ip, "::") {ip = "0"+ip}
-		if strings.HasSuffix(ip, "::") {ip = ip+"0"}
-		groups = strings.Split(ip, "::")
-		prefix := strings.Split(groups[0], ":")
-		suffix := strings.Split(groups[1], ":")
-		for l := 0; l < 8-(len(prefix)+len(suffix)); l++ {
-			zeros = append(zeros, "0000")
+	if strings.Count(ip, "::") > 1 {
+		return 0, 0, parseErr(Componentip, strings.LastIndex(ip, "::"), "IP formatted incorrectly", ErrFormat)
+	}
+	// Replace trailing dotted quad of embedded IPv4 with the last 2 groups
+	if last := strings.LastIndex(ip, ":"); strings.Contains(ip, ".") {
+		if strings.Contains(ip[:last], ".") {return 0, 0, parseErr(Componentip, strings.Index(ip, "."), "IP formatted incorrectly", ErrFormat)}
+		v4, err := parsev4(ip[last+1:])
+		if err != nil {return 0, 0, atOffset(err, last+1)}
+		ip = ip[:last+1]+strconv.FormatUint(v4>>16, 16)+":"+strconv.FormatUint(v4&0xffff, 16)
+	}
+	var (
+		groups []string
+		offsets []int
+	)
+	if n := strings.Index(ip, "::"); n != -1 {
+		groups, offsets = splitGroups(ip[:n], 0)
+		suffix, suffixoffsets := splitGroups(ip[n+2:], n+2)
+		if len(groups)+len(suffix) > 7 {return 0, 0, parseErr(Componentip, n, "IP formatted incorrectly", ErrFormat)}
+		for len(groups)+len(suffix) < 8 {
+			groups = append(groups, "0")
+			offsets = append(offsets, n)
 		}
-		groups = strings.Split(groups[0]+":"+strings.Join(zeros, ":")+":"+groups[1], ":")
+		groups = append(groups, suffix...)
+		offsets = append(offsets, suffixoffsets...)
 	} else {
-		groups = strings.Split(ip, ":")
-		if len(groups) != 8 {return 0, 0, errors.New("IP formatted incorrectly")}
+		groups, offsets = splitGroups(ip, 0)
+		if len(groups) != 8 {return 0, 0, parseErr(Componentip, 0, "IP formatted incorrectly", ErrFormat)}
+	}
+	for l, group := range groups {
+		value, err := strconv.ParseUint(group, 16, 16)
+		if err != nil {return 0, 0, parseErr(Componentgroup, offsets[l], ordinals[l]+" group malformed", ErrGroup)}
+		if l < 4 {pipof = pipof<<16 | value
+		} else {pip = pip<<16 | value}
 	}
-	group1, err := strconv.ParseUint(groups[0], 16, 16)
-	if err != nil {return 0, 0, errors.New("First group malformed")}
-	group2, err := strconv.ParseUint(groups[1], 16, 16)
-	if err != nil {return 0, 0, errors.New("Second group malformed")}
-	group3, err := strconv.ParseUint(groups[2], 16, 16)
-	if err != nil {return 0, 0, errors.New("Third group malformed")}
-	group4, err := strconv.ParseUint(groups[3], 16, 16)
-	if err != nil {return 0, 0, errors.New("Fourth group malformed")}
-	group5, err := strconv.ParseUint(groups[4], 16, 16)
-	if err != nil {return 0, 0, errors.New("Fifth group malformed")}
-	group6, err := strconv.ParseUint(groups[5], 16, 16)
-	if err != nil {return 0, 0, errors.New("Sixth group malformed")}
-	group7, err := strconv.ParseUint(groups[6], 16, 16)
-	if err != nil {return 0, 0, errors.New("Seventh group malformed")}
-	group8, err := strconv.ParseUint(groups[7], 16, 16)
-	if err != nil {return 0, 0, errors.New("Eighth group malformed")}
-	pip = group5<<48 | group6<<32 | group7<<16 | group8
-	pipof = group1<<48 | group2<<32 | group3<<16 | group4
 	return pip, pipof, nil
 }
 
@@ -150,66 +176,27 @@ func v4tostr(v4 uint64) (string) {
 	return builder.String()
 }
 
-func v6tostr(v6, v6of uint64) (v6str string) {
-	var builder strings.Builder
-	builder.WriteString(":")
-	builder.WriteString(strconv.FormatUint((v6of>>48 & 0xffff), 16))
-	builder.WriteString(":")
-	builder.WriteString(strconv.FormatUint((v6of>>32 & 0xffff), 16))
-	builder.WriteString(":")
-	builder.WriteString(strconv.FormatUint((v6of>>16 & 0xffff), 16))
-	builder.WriteString(":")
-	builder.WriteString(strconv.FormatUint((v6of & 0xffff), 16))
-	builder.WriteString(":")
-	builder.WriteString(strconv.FormatUint((v6>>48 & 0xffff), 16))
-	builder.WriteString(":")
-	builder.WriteString(strconv.FormatUint((v6>>32 & 0xffff), 16))
-	builder.WriteString(":")
-	builder.WriteString(strconv.FormatUint((v6>>16 & 0xffff), 16))
-	builder.WriteString(":")
-	builder.WriteString(strconv.FormatUint((v6 & 0xffff), 16))
-	builder.WriteString(":")
-	v6str =	builder.String()
-	if strings.Contains(v6str, ":0:0:") {
-		v6str = strings.Replace(v6str, ":0:0:0:0:0:0:0:0:", "::", 1)
-		v6str = strings.Replace(v6str, ":0:0:0:0:0:0:0:", "::", 1)
-		v6str = strings.Replace(v6str, ":0:0:0:0:0:0:", "::", 1)
-		v6str = strings.Replace(v6str, ":0:0:0:0:0:", "::", 1)
-		v6str = strings.Replace(v6str, ":0:0:0:0:", "::", 1)
-		if !strings.Contains(v6str, "::") {
-			v6str = strings.Replace(v6str, ":0:0:0:", "::", 1)
-			v6str = strings.Replace(v6str, ":0:0:", "::", 1)
-		}
-	}
-	if !strings.HasPrefix(v6str, "::") {v6str = strings.TrimPrefix(v6str, ":")}
-	if !strings.HasSuffix(v6str, "::") {v6str = strings.TrimSuffix(v6str, ":")}
-	return
+func v6tostr(v6, v6of uint64) (string) {
+	groups := v6groups(v6, v6of)
+	return formatGroups(groups[:], Stylecanonical)
 }
 
 func parseLimit(ip, ipof, rmask, rmaskof uint64) (uint64, uint64) {
 	return ip | rmask, ipof | rmaskof
 }
 
-// Public functions
+func maxPrefix(isv6 bool) (int) {
+	if isv6 {return 128
+	} else {return 32}
+}
 
-// Initialize new instance of Ipinfo
-func NewIP(ip string) (*Ipinfo, error) {
-	isv6, err := ipv(ip)
-	if err != nil {return nil, err}
-	pip, pipof, prefixlen, err := parse(ip, isv6)
-	if err != nil {return nil, err}
-	if isv6 && prefixlen > 128 {
-		err = errors.New("Prefix length too large")
-		return nil, err
-	}
-	if !isv6 && prefixlen > 32 {
-		err = errors.New("Prefix length too large")
-		return nil, err
-	}
-	if prefixlen < 0 {
-		err = errors.New("Prefix length cannot be negative")
-		return nil, err
-	}
+func checkPrefix(prefixlen int, isv6 bool) (error) {
+	if prefixlen > maxPrefix(isv6) {return parseErr(Componentprefix, 0, "Prefix length too large", ErrPrefixrange)}
+	if prefixlen < 0 {return parseErr(Componentprefix, 0, "Prefix length cannot be negative", ErrPrefixrange)}
+	return nil
+}
+
+func newIP(pip, pipof uint64, prefixlen int, isv6 bool) (*Ipinfo) {
 	suffixlen, mask, maskof, rmask, rmaskof := parseMasks(prefixlen, isv6)
 	prefix, prefixof := parsePrefix(pip, pipof, mask, maskof)
 	limit, limitof := parseLimit(pip, pipof, rmask, rmaskof)
@@ -227,10 +214,60 @@ func NewIP(ip string) (*Ipinfo, error) {
 		prefixlen: prefixlen,
 		suffixlen: suffixlen,
 		isv6: isv6}
-	return &newip, nil
+	return &newip
+}
+
+// Set zone of new instance of Ipinfo, ignoring zone for IPv4
+func (i *Ipinfo) withZone(zone string) (*Ipinfo) {
+	if i.isv6 {i.zone = zone}
+	return i
+}
+
+// Public functions
+
+// Initialize new instance of Ipinfo
+func NewIP(ip string) (*Ipinfo, error) {
+	addr, zone, n, err := splitZone(ip)
+	if err != nil {return nil, withInput(err, ip)}
+	isv6, err := ipv(addr)
+	if err != nil {return nil, withInput(err, ip)}
+	if zone != "" && !isv6 {return nil, withInput(parseErr(Componentzone, n, "Zone not allowed for IPv4", ErrZone), ip)}
+	pip, pipof, prefixlen, err := parse(addr, isv6)
+	if err != nil {
+		// Shift offsets past the zone back to their place in the input
+		var perr *ParseError
+		if zone != "" && errors.As(err, &perr) && perr.Offset >= n {perr.Offset += len(zone)+1}
+		return nil, withInput(err, ip)
+	}
+	return newIP(pip, pipof, prefixlen, isv6).withZone(zone), nil
+}
+
+// Initialize new instance of Ipinfo from 2 uint64, lower and upper bits, of IP and prefix length
+func FromInts(ip, ipof uint64, prefixlen int, isv6 bool) (*Ipinfo, error) {
+	if !isv6 && (ipof != 0 || ip > 0xffffffff) {return nil, errors.New("IP out of IPv4 address space")}
+	err := checkPrefix(prefixlen, isv6)
+	if err != nil {return nil, err}
+	return newIP(ip, ipof, prefixlen, isv6), nil
+}
+
+// Initialize new instance of Ipinfo from 4 byte IPv4 or 16 byte IPv6 slice and prefix length
+func FromBytes(b []byte, prefixlen int) (*Ipinfo, error) {
+	ip, ipof, isv6, err := fromBytes(b)
+	if err != nil {return nil, err}
+	return FromInts(ip, ipof, prefixlen, isv6)
+}
+
+// Initialize new instance of Ipinfo from 4 byte IPv4 array and prefix length
+func From4(b [4]byte, prefixlen int) (*Ipinfo, error) {
+	return FromBytes(b[:], prefixlen)
 }
 
-// Convert 2 uint64, lower and upper bits, to string
+// Initialize new instance of Ipinfo from 16 byte IPv6 array and prefix length
+func From16(b [16]byte, prefixlen int) (*Ipinfo, error) {
+	return FromBytes(b[:], prefixlen)
+}
+
+// Convert 2 uint64, lower and upper bits, to string in canonical RFC 5952 notation
 func Iptostr(ip, ipof uint64, isv6 bool) (string) {
 	if isv6 {return v6tostr(ip, ipof)
 	} else {return v4tostr(ip)}
@@ -241,11 +278,24 @@ func (i Ipinfo) Ipint() (uint64, uint64) {
 	return i.ip, i.ipof
 }
 
-// Return IP string
+// Return IP string, including IPv6 zone if any
 func (i Ipinfo) Ip() (string) {
+	if i.zone != "" {return Iptostr(i.ip, i.ipof, i.isv6)+"%"+i.zone}
 	return Iptostr(i.ip, i.ipof, i.isv6)
 }
 
+// Return IPv6 zone, or empty string if none, the zone being part of identity as in netip.Addr so that IPs in different zones
+// or with and without a zone are never equal to Compare, Comparenet, Relate, Contains or Overlaps, while Ipset, Prefixmap,
+// Rangemap, Aggregate and Exclude work on address space alone and ignore zones
+func (i Ipinfo) Zone() (string) {
+	return i.zone
+}
+
+// Return byte slice of IP, 4 bytes for IPv4 and 16 bytes for IPv6
+func (i Ipinfo) Bytes() ([]byte) {
+	return toBytes(i.ip, i.ipof, i.isv6)
+}
+
 // Return prefix length
 func (i Ipinfo) Prefixlen() (int) {
 	return i.prefixlen
@@ -312,7 +362,7 @@ func (i Ipinfo) Limit() (string) {
 	return Iptostr(i.limit, i.limitof, i.isv6)
 }
 
-// Check if IP is within network and return error if not
+// Check if IP is within network and return error if not, zones not being considered
 func (i Ipinfo) Ispeer(ip, ipof uint64) (bool, error) {
 	if ipof > i.limitof || (ipof == i.limitof && ip > i.limit) {
 		return false, errors.New("IP ascends out of network bounds")
@@ -0,0 +1,71 @@
+package goIP
+
+import (
+	"testing"
+)
+
+// Tests
+
+func TestFromBytes(t *testing.T) {
+	tests := []struct {
+		b []byte
+		prefixlen int
+		want string
+	}{
+		{[]byte{192, 0, 2, 1}, 24, "192.0.2.1/24"},
+		{[]byte{0, 0, 0, 0}, 0, "0.0.0.0/0"},
+		{[]byte{255, 255, 255, 255}, 32, "255.255.255.255/32"},
+		{[]byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, 64, "2001:db8::1/64"},
+		{[]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 1, 2, 3, 4}, 128, "::ffff:102:304/128"},
+		{[]byte{192, 0, 2, 1}, 33, ""},
+		{[]byte{192, 0, 2, 1}, -1, ""},
+		{make([]byte, 16), 129, ""},
+		{nil, 0, ""},
+		{[]byte{1, 2, 3}, 0, ""},
+		{make([]byte, 5), 0, ""},
+		{make([]byte, 15), 0, ""},
+		{make([]byte, 17), 0, ""},
+	}
+	for _, test := range tests {
+		got, err := FromBytes(test.b, test.prefixlen)
+		if test.want == "" {
+			if err == nil {t.Errorf("FromBytes(%v, %d) = %s, want error", test.b, test.prefixlen, got)}
+			continue
+		}
+		if err != nil || got.String() != test.want {
+			t.Errorf("FromBytes(%v, %d) = %v, %v, want %s", test.b, test.prefixlen, got, err, test.want)
+			continue
+		}
+		if b := got.Bytes(); string(b) != string(test.b) {t.Errorf("Bytes() of %s = %v", test.want, b)}
+	}
+	if got, err := From4([4]byte{10, 0, 0, 1}, 8); err != nil || got.String() != "10.0.0.1/8" || got.Prefix() != "10.0.0.0" {t.Errorf("From4 = %v, %v", got, err)}
+	if got, err := From16([16]byte{15: 1}, 128); err != nil || got.String() != "::1/128" {t.Errorf("From16 = %v, %v", got, err)}
+}
+
+func TestFromInts(t *testing.T) {
+	tests := []struct {
+		ip, ipof uint64
+		prefixlen int
+		isv6 bool
+		want string
+	}{
+		{0xc0000201, 0, 24, false, "192.0.2.1/24"},
+		{0xffffffff, 0, 32, false, "255.255.255.255/32"},
+		{0x100000000, 0, 32, false, ""},
+		{1, 1, 32, false, ""},
+		{1, 0, 33, false, ""},
+		{1, 0x20010db800000000, 64, true, "2001:db8::1/64"},
+		{0, 0, 129, true, ""},
+		{0, 0, -1, true, ""},
+	}
+	for _, test := range tests {
+		got, err := FromInts(test.ip, test.ipof, test.prefixlen, test.isv6)
+		if test.want == "" {
+			if err == nil {t.Errorf("FromInts(%x, %x, %d, %v) = %s, want error", test.ip, test.ipof, test.prefixlen, test.isv6, got)}
+			continue
+		}
+		if err != nil || got.String() != test.want {t.Errorf("FromInts(%x, %x, %d, %v) = %v, %v, want %s", test.ip, test.ipof, test.prefixlen, test.isv6, got, err, test.want)}
+		// Masks, prefix and limit match NewIP
+		if want := mustIP(t, test.want); *got != *want {t.Errorf("FromInts(%x, %x, %d, %v) = %+v, want %+v", test.ip, test.ipof, test.prefixlen, test.isv6, got, want)}
+	}
+}
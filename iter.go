@@ -0,0 +1,46 @@
+package goIP
+
+import (
+	"iter"
+)
+
+// Private functions
+
+func (i Ipinfo) span(first, firstof, last, lastof uint64) iter.Seq[*Ipinfo] {
+	return func(yield func(*Ipinfo) bool) {
+		ip, ipof := first, firstof
+		for {
+			if !yield(newIP(ip, ipof, i.prefixlen, i.isv6).withZone(i.zone)) {return}
+			if ip == last && ipof == lastof {return}
+			ip, ipof, _ = add128(ip, ipof, 1, 0)
+		}
+	}
+}
+
+// Public functions
+
+// Return iterator over every IP from network prefix to network upper bound
+func (i Ipinfo) Hosts() iter.Seq[*Ipinfo] {
+	return i.span(i.prefix, i.prefixof, i.limit, i.limitof)
+}
+
+// Return iterator over usable IPs, skipping IPv4 network and broadcast addresses when prefix length is 30 or less
+func (i Ipinfo) Usablehosts() iter.Seq[*Ipinfo] {
+	if i.isv6 || i.prefixlen > 30 {return i.Hosts()}
+	return i.span(i.prefix+1, i.prefixof, i.limit-1, i.limitof)
+}
+
+// Return iterator over every subnet of given prefix length within network, or nothing if prefix length out of range
+func (i Ipinfo) Subnets(prefixlen int) iter.Seq[*Ipinfo] {
+	return func(yield func(*Ipinfo) bool) {
+		if prefixlen < i.prefixlen || prefixlen > maxPrefix(i.isv6) {return}
+		_, _, _, rmask, rmaskof := parseMasks(prefixlen, i.isv6)
+		ip, ipof := i.prefix, i.prefixof
+		for {
+			if !yield(newIP(ip, ipof, prefixlen, i.isv6).withZone(i.zone)) {return}
+			limit, limitof := parseLimit(ip, ipof, rmask, rmaskof)
+			if limit == i.limit && limitof == i.limitof {return}
+			ip, ipof, _ = add128(limit, limitof, 1, 0)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package goIP
+
+import (
+	"slices"
+	"testing"
+)
+
+// Private functions
+
+func ipStrings(ips []*Ipinfo) ([]string) {
+	var strs []string
+	for _, ip := range ips {strs = append(strs, ip.Ip())}
+	return strs
+}
+
+// Tests
+
+func TestHosts(t *testing.T) {
+	tests := []struct {
+		in string
+		hosts, usable []string
+	}{
+		{"10.0.0.5/29", []string{"10.0.0.0", "10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5", "10.0.0.6", "10.0.0.7"},
+			[]string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5", "10.0.0.6"}},
+		{"10.0.0.4/30", []string{"10.0.0.4", "10.0.0.5", "10.0.0.6", "10.0.0.7"}, []string{"10.0.0.5", "10.0.0.6"}},
+		{"10.0.0.4/31", []string{"10.0.0.4", "10.0.0.5"}, []string{"10.0.0.4", "10.0.0.5"}},
+		{"10.0.0.4/32", []string{"10.0.0.4"}, []string{"10.0.0.4"}},
+		{"255.255.255.254/31", []string{"255.255.255.254", "255.255.255.255"}, []string{"255.255.255.254", "255.255.255.255"}},
+		{"2001:db8::/126", []string{"2001:db8::", "2001:db8::1", "2001:db8::2", "2001:db8::3"},
+			[]string{"2001:db8::", "2001:db8::1", "2001:db8::2", "2001:db8::3"}},
+		{"::ffff:ffff:ffff:ffff/127", []string{"::ffff:ffff:ffff:fffe", "::ffff:ffff:ffff:ffff"}, []string{"::ffff:ffff:ffff:fffe", "::ffff:ffff:ffff:ffff"}},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128", []string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"}, []string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"}},
+	}
+	for _, test := range tests {
+		ip := mustIP(t, test.in)
+		if got := ipStrings(slices.Collect(ip.Hosts())); !slices.Equal(got, test.hosts) {t.Errorf("%s Hosts() = %v, want %v", test.in, got, test.hosts)}
+		if got := ipStrings(slices.Collect(ip.Usablehosts())); !slices.Equal(got, test.usable) {t.Errorf("%s Usablehosts() = %v, want %v", test.in, got, test.usable)}
+	}
+	// Stopping early ends iteration without running to the upper bound
+	count := 0
+	for range mustIP(t, "::/0").Hosts() {
+		count++
+		if count == 3 {break}
+	}
+	if count != 3 {t.Errorf("Hosts() of ::/0 stopped after %d IPs", count)}
+}
+
+func TestSubnets(t *testing.T) {
+	tests := []struct {
+		in string
+		prefixlen int
+		want []string
+	}{
+		{"10.0.0.0/24", 26, []string{"10.0.0.0/26", "10.0.0.64/26", "10.0.0.128/26", "10.0.0.192/26"}},
+		{"10.0.0.0/24", 24, []string{"10.0.0.0/24"}},
+		{"10.0.0.0/24", 23, nil},
+		{"10.0.0.0/24", 33, nil},
+		{"255.255.255.252/30", 31, []string{"255.255.255.252/31", "255.255.255.254/31"}},
+		{"2001:db8::/63", 64, []string{"2001:db8::/64", "2001:db8:0:1::/64"}},
+		{"2001:db8::/64", 65, []string{"2001:db8::/65", "2001:db8:0:0:8000::/65"}},
+	}
+	for _, test := range tests {
+		if got := netStrings(slices.Collect(mustIP(t, test.in).Subnets(test.prefixlen))); !slices.Equal(got, test.want) {
+			t.Errorf("%s Subnets(%d) = %v, want %v", test.in, test.prefixlen, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package goIP
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Public Ipflag struct wrapping Ipinfo as a flag.Value, nil until set
+type Ipflag struct {
+	Ip *Ipinfo
+}
+
+// Public Iplistflag type collecting networks from repeated or comma separated flags as a flag.Value
+type Iplistflag []*Ipinfo
+
+// Public functions
+
+// Implement encoding.TextMarshaler using CIDR notation
+func (i Ipinfo) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// Implement encoding.TextUnmarshaler, parsing the same as NewIP
+func (i *Ipinfo) UnmarshalText(text []byte) (error) {
+	newip, err := NewIP(string(text))
+	if err != nil {return err}
+	*i = *newip
+	return nil
+}
+
+// Implement json.Marshaler as a string in CIDR notation
+func (i Ipinfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.String())
+}
+
+// Implement json.Unmarshaler from a string parsed the same as NewIP, leaving Ipinfo unchanged on null
+func (i *Ipinfo) UnmarshalJSON(data []byte) (error) {
+	if string(data) == "null" {return nil}
+	var text string
+	err := json.Unmarshal(data, &text)
+	if err != nil {return err}
+	return i.UnmarshalText([]byte(text))
+}
+
+// Implement encoding.BinaryMarshaler as IP version byte, prefix length byte, 4 or 16 IP bytes, then any zone
+func (i Ipinfo) MarshalBinary() ([]byte, error) {
+	data := append([]byte{byte(i.V()), byte(i.prefixlen)}, toBytes(i.ip, i.ipof, i.isv6)...)
+	return append(data, i.zone...), nil
+}
+
+// Implement encoding.BinaryUnmarshaler for data written by MarshalBinary
+func (i *Ipinfo) UnmarshalBinary(data []byte) (error) {
+	if len(data) < 2 {return errors.New("Binary IP too short")}
+	var size int
+	switch data[0] {
+	case 4: size = 4
+	case 6: size = 16
+	default: return errors.New("Binary IP version unknown")
+	}
+	if len(data) < 2+size || (size == 4 && len(data) != 6) {return errors.New("Binary IP length incorrect")}
+	newip, err := FromBytes(data[2:2+size], int(data[1]))
+	if err != nil {return err}
+	*i = *newip.withZone(string(data[2+size:]))
+	return nil
+}
+
+// Return network string in CIDR notation, or empty string if not set
+func (f *Ipflag) String() (string) {
+	if f == nil || f.Ip == nil {return ""}
+	return f.Ip.String()
+}
+
+// Parse flag the same as NewIP
+func (f *Ipflag) Set(value string) (error) {
+	newip, err := NewIP(value)
+	if err != nil {return err}
+	f.Ip = newip
+	return nil
+}
+
+// Implement flag.Getter, returning *Ipinfo
+func (f *Ipflag) Get() (any) {
+	return f.Ip
+}
+
+// Return comma separated networks in CIDR notation
+func (l *Iplistflag) String() (string) {
+	if l == nil {return ""}
+	nets := make([]string, len(*l))
+	for n, ip := range *l {nets[n] = ip.String()}
+	return strings.Join(nets, ",")
+}
+
+// Parse comma separated flag, each the same as NewIP, appending to list only if all parse
+func (l *Iplistflag) Set(value string) (error) {
+	var nets []*Ipinfo
+	for _, token := range strings.Split(value, ",") {
+		newip, err := NewIP(strings.TrimSpace(token))
+		if err != nil {return err}
+		nets = append(nets, newip)
+	}
+	*l = append(*l, nets...)
+	return nil
+}
+
+// Implement flag.Getter, returning []*Ipinfo
+func (l *Iplistflag) Get() (any) {
+	return []*Ipinfo(*l)
+}
@@ -0,0 +1,76 @@
+package goIP
+
+import (
+	"encoding/json"
+	"flag"
+	"slices"
+	"testing"
+)
+
+// Tests
+
+func TestMarshalRoundtrip(t *testing.T) {
+	for _, in := range []string{"10.0.0.5/24", "0.0.0.0/0", "255.255.255.255/32", "2001:db8::1/64", "::/0", "fe80::1%eth0/64", "::ffff:1.2.3.4/128"} {
+		ip := mustIP(t, in)
+		text, _ := ip.MarshalText()
+		var fromtext Ipinfo
+		if err := fromtext.UnmarshalText(text); err != nil || fromtext != *ip {t.Errorf("Text round trip of %s = %s, %v", in, fromtext, err)}
+		data, err := json.Marshal(ip)
+		if err != nil || string(data) != `"`+ip.String()+`"` {t.Errorf("json.Marshal(%s) = %s, %v", in, data, err)}
+		var fromjson Ipinfo
+		if err := json.Unmarshal(data, &fromjson); err != nil || fromjson != *ip {t.Errorf("JSON round trip of %s = %s, %v", in, fromjson, err)}
+		bin, _ := ip.MarshalBinary()
+		var frombin Ipinfo
+		if err := frombin.UnmarshalBinary(bin); err != nil || frombin != *ip {t.Errorf("Binary round trip of %s = %s, %v", in, frombin, err)}
+	}
+	if bin, _ := mustIP(t, "10.0.0.5/24").MarshalBinary(); string(bin) != string([]byte{4, 24, 10, 0, 0, 5}) {t.Errorf("MarshalBinary of 10.0.0.5/24 = %v", bin)}
+	if bin, _ := mustIP(t, "fe80::1%eth0/64").MarshalBinary(); len(bin) != 22 || string(bin[18:]) != "eth0" {t.Errorf("MarshalBinary of fe80::1%%eth0/64 = %v", bin)}
+}
+
+func TestUnmarshalMalformed(t *testing.T) {
+	binaries := [][]byte{
+		nil,
+		{4},
+		{5, 24, 10, 0, 0, 5},
+		{4, 24, 10, 0, 0},
+		{4, 24, 10, 0, 0, 5, 'x'},
+		{4, 33, 10, 0, 0, 5},
+		{6, 64, 0x20, 0x01},
+		{6, 129, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+	}
+	for _, data := range binaries {
+		ip := *mustIP(t, "192.0.2.1/32")
+		if err := ip.UnmarshalBinary(data); err == nil {t.Errorf("UnmarshalBinary(%v) accepted as %s", data, ip)}
+		if ip.String() != "192.0.2.1/32" {t.Errorf("UnmarshalBinary(%v) changed Ipinfo on error", data)}
+	}
+	for _, data := range []string{`1`, `"10.0.0.x"`, `["10.0.0.1"]`, `"10.0.0.1`, `{}`} {
+		var ip Ipinfo
+		if err := json.Unmarshal([]byte(data), &ip); err == nil {t.Errorf("json.Unmarshal(%s) accepted", data)}
+	}
+	ip := mustIP(t, "192.0.2.1/32")
+	if err := json.Unmarshal([]byte(`null`), ip); err != nil || ip.String() != "192.0.2.1/32" {t.Errorf("json.Unmarshal(null) = %s, %v", ip, err)}
+	var holder struct {Ip *Ipinfo}
+	if err := json.Unmarshal([]byte(`{"Ip": null}`), &holder); err != nil || holder.Ip != nil {t.Errorf("json.Unmarshal of null field = %v, %v", holder.Ip, err)}
+	if err := json.Unmarshal([]byte(`{"Ip": "10.0.0.1/8"}`), &holder); err != nil || holder.Ip.String() != "10.0.0.1/8" {t.Errorf("json.Unmarshal of field = %v, %v", holder.Ip, err)}
+}
+
+func TestFlags(t *testing.T) {
+	var (
+		single Ipflag
+		list Iplistflag
+	)
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&single, "ip", "")
+	fs.Var(&list, "nets", "")
+	if single.String() != "" || list.String() != "" {t.Error("Unset flags not empty")}
+	err := fs.Parse([]string{"-ip", "10.0.0.1/8", "-nets", "10.0.0.0/8, 2001:db8::/32", "-nets", "192.0.2.0/24"})
+	if err != nil {t.Fatal(err)}
+	if single.String() != "10.0.0.1/8" || single.Get().(*Ipinfo).Prefix() != "10.0.0.0" {t.Errorf("Ipflag = %s", single.String())}
+	if want := "10.0.0.0/8,2001:db8::/32,192.0.2.0/24"; list.String() != want {t.Errorf("Iplistflag = %s, want %s", list.String(), want)}
+	if err := list.Set("10.0.0.0/8,10.0.0.x"); err == nil {t.Error("Iplistflag accepted malformed network")}
+	if got := netStrings(list.Get().([]*Ipinfo)); !slices.Equal(got, []string{"10.0.0.0/8", "2001:db8::/32", "192.0.2.0/24"}) {t.Errorf("Iplistflag after failed Set = %v", got)}
+	if err := single.Set("10.0.0.x"); err == nil || single.String() != "10.0.0.1/8" {t.Errorf("Ipflag Set of malformed IP = %v, left %s", err, single.String())}
+	var nilflag *Ipflag
+	var nillist *Iplistflag
+	if nilflag.String() != "" || nillist.String() != "" {t.Error("Nil flags not empty")}
+}
@@ -0,0 +1,86 @@
+package goIP
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"net/netip"
+)
+
+// Private functions
+
+func toBytes(ip, ipof uint64, isv6 bool) ([]byte) {
+	if !isv6 {return binary.BigEndian.AppendUint32(nil, uint32(ip))}
+	return binary.BigEndian.AppendUint64(binary.BigEndian.AppendUint64(nil, ipof), ip)
+}
+
+func fromBytes(b []byte) (ip, ipof uint64, isv6 bool, err error) {
+	switch len(b) {
+	case 4: return uint64(binary.BigEndian.Uint32(b)), 0, false, nil
+	case 16: return binary.BigEndian.Uint64(b[8:]), binary.BigEndian.Uint64(b[:8]), true, nil
+	}
+	return 0, 0, false, errors.New("IP must be 4 or 16 bytes")
+}
+
+// Public functions
+
+// Initialize new instance of Ipinfo from netip.Addr with full length prefix, keeping IPv4-mapped IPv6 as IPv6
+func FromNetipaddr(a netip.Addr) (*Ipinfo, error) {
+	if !a.IsValid() {return nil, errors.New("Invalid netip.Addr")}
+	ip, ipof, isv6, _ := fromBytes(a.AsSlice())
+	return newIP(ip, ipof, a.BitLen(), isv6).withZone(a.Zone()), nil
+}
+
+// Initialize new instance of Ipinfo from netip.Prefix, keeping IPv4-mapped IPv6 as IPv6
+func FromNetipprefix(p netip.Prefix) (*Ipinfo, error) {
+	if !p.IsValid() {return nil, errors.New("Invalid netip.Prefix")}
+	ip, ipof, isv6, _ := fromBytes(p.Addr().AsSlice())
+	return newIP(ip, ipof, p.Bits(), isv6), nil
+}
+
+// Initialize new instance of Ipinfo from legacy net.IP with full length prefix, treating IPv4-mapped IPv6 as IPv4 as package net does
+func FromLegacyip(ip net.IP) (*Ipinfo, error) {
+	if v4 := ip.To4(); v4 != nil {ip = v4}
+	pip, pipof, isv6, err := fromBytes(ip)
+	if err != nil {return nil, err}
+	return newIP(pip, pipof, maxPrefix(isv6), isv6), nil
+}
+
+// Initialize new instance of Ipinfo from *net.IPNet
+func FromIpnet(n *net.IPNet) (*Ipinfo, error) {
+	if n == nil {return nil, errors.New("Nil net.IPNet")}
+	ones, bits := n.Mask.Size()
+	ip := n.IP
+	switch bits {
+	case 32: ip = ip.To4()
+	case 128: ip = ip.To16()
+	default: return nil, errors.New("Mask of net.IPNet not canonical")
+	}
+	pip, pipof, isv6, err := fromBytes(ip)
+	if err != nil {return nil, err}
+	return newIP(pip, pipof, ones, isv6), nil
+}
+
+// Return netip.Addr of IP, including IPv6 zone if any
+func (i Ipinfo) Netipaddr() (netip.Addr) {
+	a, _ := netip.AddrFromSlice(toBytes(i.ip, i.ipof, i.isv6))
+	return a.WithZone(i.zone)
+}
+
+// Return netip.Prefix of IP and prefix length, dropping any zone as netip.Prefix cannot hold one
+func (i Ipinfo) Netipprefix() (netip.Prefix) {
+	return netip.PrefixFrom(i.Netipaddr().WithZone(""), i.prefixlen)
+}
+
+// Return legacy net.IP of IP, 4 bytes for IPv4 and 16 bytes for IPv6, IPv4-mapped IPv6 reading back as IPv4 through FromLegacyip
+// as net.IP cannot tell them apart, unlike *net.IPNet whose mask length keeps the IP version
+func (i Ipinfo) Legacyip() (net.IP) {
+	return net.IP(toBytes(i.ip, i.ipof, i.isv6))
+}
+
+// Return *net.IPNet of network
+func (i Ipinfo) Ipnet() (*net.IPNet) {
+	return &net.IPNet{
+		IP: net.IP(toBytes(i.prefix, i.prefixof, i.isv6)),
+		Mask: net.CIDRMask(i.prefixlen, maxPrefix(i.isv6))}
+}
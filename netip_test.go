@@ -0,0 +1,76 @@
+package goIP
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+)
+
+// Tests
+
+func TestNetipRoundtrip(t *testing.T) {
+	for _, in := range []string{"0.0.0.0/32", "192.0.2.1/32", "255.255.255.255/32", "::/128", "2001:db8::1/128", "::ffff:1.2.3.4/128", "fe80::1%eth0/128"} {
+		ip := mustIP(t, in)
+		a := ip.Netipaddr()
+		if a != netip.MustParseAddr(ip.Ip()) {t.Errorf("%s Netipaddr() = %s", in, a)}
+		got, err := FromNetipaddr(a)
+		if err != nil || got.String() != ip.String() {t.Errorf("FromNetipaddr(%s Netipaddr()) = %v, %v", in, got, err)}
+	}
+	if _, err := FromNetipaddr(netip.Addr{}); err == nil {t.Error("FromNetipaddr accepted zero netip.Addr")}
+}
+
+func TestNetipprefixRoundtrip(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"10.0.0.5/24", "10.0.0.5/24"},
+		{"0.0.0.0/0", "0.0.0.0/0"},
+		{"2001:db8::1/64", "2001:db8::1/64"},
+		{"::ffff:1.2.3.4/120", "::ffff:102:304/120"},
+		{"fe80::1%eth0/64", "fe80::1/64"},
+	}
+	for _, test := range tests {
+		p := mustIP(t, test.in).Netipprefix()
+		got, err := FromNetipprefix(p)
+		if err != nil || got.String() != test.want {t.Errorf("FromNetipprefix(%s Netipprefix()) = %v, %v, want %s", test.in, got, err, test.want)}
+	}
+	if _, err := FromNetipprefix(netip.Prefix{}); err == nil {t.Error("FromNetipprefix accepted zero netip.Prefix")}
+}
+
+func TestLegacyipRoundtrip(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"192.0.2.1/32", "192.0.2.1/32"},
+		{"2001:db8::1/128", "2001:db8::1/128"},
+		{"::/128", "::/128"},
+		// net.IP cannot tell IPv4-mapped IPv6 from IPv4
+		{"::ffff:1.2.3.4/128", "1.2.3.4/32"},
+	}
+	for _, test := range tests {
+		got, err := FromLegacyip(mustIP(t, test.in).Legacyip())
+		if err != nil || got.String() != test.want {t.Errorf("FromLegacyip(%s Legacyip()) = %v, %v, want %s", test.in, got, err, test.want)}
+	}
+	if got, err := FromLegacyip(net.ParseIP("192.0.2.1")); err != nil || got.String() != "192.0.2.1/32" {t.Errorf("FromLegacyip of 16 byte IPv4 = %v, %v", got, err)}
+	for _, ip := range []net.IP{nil, net.IP{1, 2, 3}} {
+		if _, err := FromLegacyip(ip); err == nil {t.Errorf("FromLegacyip(%v) accepted", []byte(ip))}
+	}
+}
+
+func TestIpnetRoundtrip(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"10.0.0.5/24", "10.0.0.0/24"},
+		{"0.0.0.0/0", "0.0.0.0/0"},
+		{"2001:db8::1/64", "2001:db8::/64"},
+		{"::ffff:1.2.3.4/128", "::ffff:102:304/128"},
+		{"::ffff:1.2.3.4/96", "::ffff:0:0/96"},
+	}
+	for _, test := range tests {
+		got, err := FromIpnet(mustIP(t, test.in).Ipnet())
+		if err != nil || got.String() != test.want {t.Errorf("FromIpnet(%s Ipnet()) = %v, %v, want %s", test.in, got, err, test.want)}
+	}
+	if _, err := FromIpnet(nil); err == nil {t.Error("FromIpnet accepted nil")}
+	if _, err := FromIpnet(&net.IPNet{IP: net.IP{10, 0, 0, 0}, Mask: net.IPMask{255, 0, 255, 0}}); err == nil {t.Error("FromIpnet accepted non-canonical mask")}
+}
@@ -0,0 +1,96 @@
+package goIP
+
+import (
+	"errors"
+	"strings"
+	"strconv"
+)
+
+// Public Hostpolicy type deciding how host bits set in CIDR input are handled, bare IPs being exempt
+type Hostpolicy int
+
+// Host bit policies used by Parser
+const (
+	Hostallow Hostpolicy = iota
+	Hostreject
+	Hostclear
+)
+
+// Public Prefixpolicy type deciding whether a prefix length may appear in input
+type Prefixpolicy int
+
+// Prefix length policies used by Parser
+const (
+	Prefixoptional Prefixpolicy = iota
+	Prefixrequired
+	Prefixforbidden
+)
+
+// Public Parser struct of parsing options, the zero value parsing the same as NewIP
+type Parser struct {
+	// Accept every inet_aton IPv4 notation, in which leading zeros denote octal and are never rejected
+	Aton bool
+	// Reject leading zeros in IPv4 octets and prefix length
+	Rejectzeros bool
+	Host Hostpolicy
+	Prefix Prefixpolicy
+	// IP version allowed, 4 or 6, or 0 for either
+	V int
+}
+
+// Private functions
+
+func checkZeros(ip string, isv6 bool) (error) {
+	tokens := strings.Split(ip, "/")
+	if n := strings.Index(tokens[0], "%"); n != -1 {tokens[0] = tokens[0][:n]}
+	// IPv4 octets start the input, or follow the last colon of IPv6 with embedded IPv4
+	offset := 0
+	if isv6 {offset = strings.LastIndex(tokens[0], ":")+1}
+	if !isv6 || strings.Contains(tokens[0], ".") {
+		for l, octet := range strings.Split(tokens[0][offset:], ".") {
+			if len(octet) > 1 && octet[0] == '0' {
+				return parseErr(Componentoctet, offset, ordinals[l]+" octet has leading zero", ErrOctet)
+			}
+			offset += len(octet)+1
+		}
+	}
+	if len(tokens) == 2 && len(tokens[1]) > 1 && tokens[1][0] == '0' {
+		return parseErr(Componentprefix, strings.Index(ip, "/")+1, "Prefix length has leading zero", ErrPrefix)
+	}
+	return nil
+}
+
+// Public functions
+
+// Initialize new instance of Ipinfo using parser options
+func (p Parser) Parse(ip string) (*Ipinfo, error) {
+	var (
+		newip *Ipinfo
+		err error
+	)
+	if p.V != 0 && p.V != 4 && p.V != 6 {return nil, errors.New("Parser IP version must be 0, 4 or 6")}
+	if p.Aton && !strings.Contains(ip, ":") {newip, err = ParseAton(ip)
+	} else {newip, err = NewIP(ip)}
+	if err != nil {return nil, err}
+	if p.V != 0 && p.V != newip.V() {
+		return nil, withInput(parseErr(Componentip, 0, "IPv"+strconv.Itoa(newip.V())+" not allowed", ErrVersion), ip)
+	}
+	if p.Rejectzeros && !p.Aton {
+		err = checkZeros(ip, newip.isv6)
+		if err != nil {return nil, withInput(err, ip)}
+	}
+	slash := strings.Index(ip, "/")
+	if p.Prefix == Prefixrequired && slash == -1 {
+		return nil, withInput(parseErr(Componentprefix, len(ip), "Prefix length required", ErrPrefix), ip)
+	}
+	if p.Prefix == Prefixforbidden && slash != -1 {
+		return nil, withInput(parseErr(Componentprefix, slash, "Prefix length not allowed", ErrPrefix), ip)
+	}
+	if slash != -1 && (newip.ip != newip.prefix || newip.ipof != newip.prefixof) {
+		switch p.Host {
+		case Hostreject: return nil, withInput(parseErr(Componentip, 0, "Host bits set in network", ErrHostbits), ip)
+		case Hostclear: return newIP(newip.prefix, newip.prefixof, newip.prefixlen, newip.isv6).withZone(newip.zone), nil
+		}
+	}
+	return newip, nil
+}
@@ -0,0 +1,190 @@
+package goIP
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// Private pmnode struct storing a node of the compressed trie
+type pmnode[V any] struct {
+	ip uint64
+	ipof uint64
+	prefixlen int
+	value V
+	set bool
+	child [2]*pmnode[V]
+}
+
+// Public Prefixmap struct storing values by network for longest prefix match lookups, zones being ignored
+type Prefixmap[V any] struct {
+	v4 *pmnode[V]
+	v6 *pmnode[V]
+	size int
+}
+
+// Private functions
+
+// Return bit at position n, counting from the most significant bit
+func bitAt(ip, ipof uint64, n int, isv6 bool) (int) {
+	if !isv6 {return int(ip>>(31-n) & 1)}
+	if n < 64 {return int(ipof>>(63-n) & 1)}
+	return int(ip>>(127-n) & 1)
+}
+
+// Return number of leading bits in common, up to limit
+func commonBits(ip, ipof, ip2, ipof2 uint64, limit int, isv6 bool) (int) {
+	var common int
+	if !isv6 {common = bits.LeadingZeros32(uint32(ip^ip2))
+	} else if ipof != ipof2 {common = bits.LeadingZeros64(ipof^ipof2)
+	} else {common = 64+bits.LeadingZeros64(ip^ip2)}
+	if common > limit {return limit}
+	return common
+}
+
+func maskBits(ip, ipof uint64, prefixlen int, isv6 bool) (uint64, uint64) {
+	_, mask, maskof, _, _ := parseMasks(prefixlen, isv6)
+	return parsePrefix(ip, ipof, mask, maskof)
+}
+
+func (m *Prefixmap[V]) root(isv6 bool) (**pmnode[V]) {
+	if isv6 {return &m.v6}
+	return &m.v4
+}
+
+// Find link to node of exact network, along with link to its parent
+func (m *Prefixmap[V]) find(n *Ipinfo) (link, parent **pmnode[V]) {
+	link = m.root(n.isv6)
+	for *link != nil {
+		node := *link
+		if node.prefixlen > n.prefixlen {return nil, nil}
+		if commonBits(node.ip, node.ipof, n.prefix, n.prefixof, node.prefixlen, n.isv6) < node.prefixlen {return nil, nil}
+		if node.prefixlen == n.prefixlen {return link, parent}
+		parent = link
+		link = &node.child[bitAt(n.prefix, n.prefixof, node.prefixlen, n.isv6)]
+	}
+	return nil, nil
+}
+
+// Walk nodes whose networks contain IP, from shortest to longest
+func (m *Prefixmap[V]) walk(ip *Ipinfo, yield func(*pmnode[V]) bool) {
+	maxbits := maxPrefix(ip.isv6)
+	node := *m.root(ip.isv6)
+	for node != nil {
+		if commonBits(node.ip, node.ipof, ip.ip, ip.ipof, node.prefixlen, ip.isv6) < node.prefixlen {return}
+		if node.set && !yield(node) {return}
+		if node.prefixlen == maxbits {return}
+		node = node.child[bitAt(ip.ip, ip.ipof, node.prefixlen, ip.isv6)]
+	}
+}
+
+func (node *pmnode[V]) all(isv6 bool, yield func(*Ipinfo, V) bool) (bool) {
+	if node == nil {return true}
+	if node.set && !yield(newIP(node.ip, node.ipof, node.prefixlen, isv6), node.value) {return false}
+	return node.child[0].all(isv6, yield) && node.child[1].all(isv6, yield)
+}
+
+// Public functions
+
+// Insert value for network, replacing any existing value
+func (m *Prefixmap[V]) Insert(n *Ipinfo, value V) {
+	newnode := &pmnode[V]{ip: n.prefix, ipof: n.prefixof, prefixlen: n.prefixlen, value: value, set: true}
+	link := m.root(n.isv6)
+	for {
+		node := *link
+		if node == nil {
+			*link = newnode
+			m.size++
+			return
+		}
+		limit := min(node.prefixlen, n.prefixlen)
+		common := commonBits(node.ip, node.ipof, n.prefix, n.prefixof, limit, n.isv6)
+		if common == node.prefixlen && common == n.prefixlen {
+			if !node.set {m.size++}
+			node.value = value
+			node.set = true
+			return
+		}
+		if common == node.prefixlen {
+			link = &node.child[bitAt(n.prefix, n.prefixof, node.prefixlen, n.isv6)]
+			continue
+		}
+		if common == n.prefixlen {
+			newnode.child[bitAt(node.ip, node.ipof, common, n.isv6)] = node
+		} else {
+			ip, ipof := maskBits(n.prefix, n.prefixof, common, n.isv6)
+			branch := &pmnode[V]{ip: ip, ipof: ipof, prefixlen: common}
+			branch.child[bitAt(node.ip, node.ipof, common, n.isv6)] = node
+			branch.child[bitAt(n.prefix, n.prefixof, common, n.isv6)] = newnode
+			newnode = branch
+		}
+		*link = newnode
+		m.size++
+		return
+	}
+}
+
+// Delete value for network and return bool of value found or not
+func (m *Prefixmap[V]) Delete(n *Ipinfo) (bool) {
+	link, parent := m.find(n)
+	if link == nil || !(*link).set {return false}
+	node := *link
+	var zero V
+	node.value = zero
+	node.set = false
+	m.size--
+	if node.child[0] != nil && node.child[1] != nil {return true}
+	if node.child[0] != nil {*link = node.child[0]
+	} else {*link = node.child[1]}
+	if *link != nil || parent == nil {return true}
+	// Collapse parent branch left with a single child
+	p := *parent
+	if p.set {return true}
+	if p.child[0] != nil {*parent = p.child[0]
+	} else {*parent = p.child[1]}
+	return true
+}
+
+// Return value for exact network and bool of value found or not
+func (m *Prefixmap[V]) Get(n *Ipinfo) (V, bool) {
+	link, _ := m.find(n)
+	if link == nil || !(*link).set {
+		var zero V
+		return zero, false
+	}
+	return (*link).value, true
+}
+
+// Return longest network containing IP, its value, and bool of match found or not
+func (m *Prefixmap[V]) Lookup(ip *Ipinfo) (*Ipinfo, V, bool) {
+	var best *pmnode[V]
+	m.walk(ip, func(node *pmnode[V]) bool {
+		best = node
+		return true
+	})
+	if best == nil {
+		var zero V
+		return nil, zero, false
+	}
+	return newIP(best.ip, best.ipof, best.prefixlen, ip.isv6), best.value, true
+}
+
+// Return iterator over every network containing IP and its value, from shortest to longest
+func (m *Prefixmap[V]) Covering(ip *Ipinfo) iter.Seq2[*Ipinfo, V] {
+	return func(yield func(*Ipinfo, V) bool) {
+		m.walk(ip, func(node *pmnode[V]) bool {
+			return yield(newIP(node.ip, node.ipof, node.prefixlen, ip.isv6), node.value)
+		})
+	}
+}
+
+// Return iterator over every network and its value, IPv4 before IPv6 and sorted by prefix then prefix length
+func (m *Prefixmap[V]) All() iter.Seq2[*Ipinfo, V] {
+	return func(yield func(*Ipinfo, V) bool) {
+		if m.v4.all(false, yield) {m.v6.all(true, yield)}
+	}
+}
+
+// Return number of networks stored
+func (m *Prefixmap[V]) Len() (int) {
+	return m.size
+}
@@ -0,0 +1,128 @@
+package goIP
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Private functions
+
+// Return random network near a few fixed IPs so networks nest and share bits
+func randomNet(rng *rand.Rand, isv6 bool) (*Ipinfo) {
+	if !isv6 {
+		ip := []uint64{0x0a000000, 0x0a000100, 0xc0a80000, 0xffffffff}[rng.Intn(4)] ^ uint64(rng.Intn(1024))
+		return newIP(ip, 0, []int{0, 1, 8, 16, 22, 23, 24, 25, 30, 31, 32}[rng.Intn(11)], false)
+	}
+	ipof := []uint64{0x20010db800000000, 0x20010db800000001, 0xfe80000000000000}[rng.Intn(3)]
+	ip := []uint64{0, 1, 0x8000000000000000, 0xffffffffffffffff}[rng.Intn(4)] ^ uint64(rng.Intn(256))
+	return newIP(ip, ipof, []int{0, 1, 32, 63, 64, 65, 96, 120, 127, 128}[rng.Intn(10)], true)
+}
+
+// Return longest network of model containing IP, found by linear scan
+func scanLookup(model map[Ipprefix]int, ip *Ipinfo) (Ipprefix, int, bool) {
+	var (
+		best Ipprefix
+		value int
+		found bool
+	)
+	for p, v := range model {
+		if !p.Contains(Ipaddr{ip: ip.ip, ipof: ip.ipof, isv6: ip.isv6}) {continue}
+		if !found || p.prefixlen > best.prefixlen {best, value, found = p, v, true}
+	}
+	return best, value, found
+}
+
+// Check every branch node not holding a value has two children
+func checkNodes[V any](t *testing.T, node *pmnode[V]) {
+	t.Helper()
+	if node == nil {return}
+	if !node.set && (node.child[0] == nil || node.child[1] == nil) {
+		t.Fatalf("Unset node %x %x/%d left with a single child", node.ipof, node.ip, node.prefixlen)
+	}
+	checkNodes(t, node.child[0])
+	checkNodes(t, node.child[1])
+}
+
+// Tests
+
+func TestPrefixmapRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, isv6 := range []bool{false, true} {
+		for round := 0; round < 50; round++ {
+			var m Prefixmap[int]
+			model := map[Ipprefix]int{}
+			for op := 0; op < 300; op++ {
+				n := randomNet(rng, isv6)
+				key := n.Ipprefix()
+				switch rng.Intn(3) {
+				case 0:
+					found := m.Delete(n)
+					_, want := model[key]
+					if found != want {t.Fatalf("Delete(%s) = %v, want %v", n, found, want)}
+					delete(model, key)
+				default:
+					m.Insert(n, op)
+					model[key] = op
+				}
+				checkNodes(t, m.v4)
+				checkNodes(t, m.v6)
+				if m.Len() != len(model) {t.Fatalf("Len() = %d, want %d", m.Len(), len(model))}
+				for _, q := range []*Ipinfo{n, randomNet(rng, isv6)} {
+					value, found := m.Get(q)
+					want, wantfound := model[q.Ipprefix()]
+					if found != wantfound || value != want {t.Fatalf("Get(%s) = %d, %v, want %d, %v", q, value, found, want, wantfound)}
+					ip := newIP(q.ip, q.ipof, maxPrefix(isv6), isv6)
+					got, value, found := m.Lookup(ip)
+					best, want, wantfound := scanLookup(model, ip)
+					if found != wantfound || (found && (got.Ipprefix() != best || value != want)) {
+						t.Fatalf("Lookup(%s) = %v, %d, %v, want %s, %d, %v", ip.Ip(), got, value, found, best.Ipinfo(), want, wantfound)
+					}
+				}
+			}
+			var got []*Ipinfo
+			for n, value := range m.All() {
+				if model[n.Ipprefix()] != value {t.Fatalf("All() yielded %s with %d, want %d", n, value, model[n.Ipprefix()])}
+				got = append(got, n)
+			}
+			if len(got) != len(model) || !slices.IsSortedFunc(got, Comparenet) {t.Fatalf("All() = %v, not every network in order", netStrings(got))}
+		}
+	}
+}
+
+func TestPrefixmapEdges(t *testing.T) {
+	var m Prefixmap[string]
+	for _, n := range []string{"0.0.0.0/0", "::/0", "10.0.0.1/32", "2001:db8::1/128", "10.0.0.0/24", "10.0.1.0/24"} {
+		m.Insert(mustIP(t, n), n)
+	}
+	tests := []struct {
+		ip string
+		want string
+	}{
+		{"10.0.0.1/32", "10.0.0.1/32"},
+		{"10.0.0.2/32", "10.0.0.0/24"},
+		{"10.0.1.2/32", "10.0.1.0/24"},
+		{"192.0.2.1/32", "0.0.0.0/0"},
+		{"2001:db8::1/128", "2001:db8::1/128"},
+		{"2001:db8::2/128", "::/0"},
+	}
+	for _, test := range tests {
+		if _, value, found := m.Lookup(mustIP(t, test.ip)); !found || value != test.want {
+			t.Errorf("Lookup(%s) = %s, %v, want %s", test.ip, value, found, test.want)
+		}
+	}
+	// 10.0.0.0/24 and 10.0.1.0/24 hang below an unset 10.0.0.0/23 branch, which must collapse
+	for _, n := range []string{"10.0.0.1/32", "10.0.0.0/24"} {
+		if !m.Delete(mustIP(t, n)) {t.Fatalf("Delete(%s) = false", n)}
+		checkNodes(t, m.v4)
+	}
+	if m.Delete(mustIP(t, "10.0.0.0/24")) {t.Error("Delete of deleted network = true")}
+	if m.Delete(mustIP(t, "10.0.0.0/23")) {t.Error("Delete of unset branch = true")}
+	if _, value, _ := m.Lookup(mustIP(t, "10.0.0.1/32")); value != "0.0.0.0/0" {t.Errorf("Lookup(10.0.0.1) after Delete = %s", value)}
+	if _, value, _ := m.Lookup(mustIP(t, "10.0.1.1/32")); value != "10.0.1.0/24" {t.Errorf("Lookup(10.0.1.1) after Delete = %s", value)}
+	for _, n := range []string{"0.0.0.0/0", "::/0", "10.0.1.0/24", "2001:db8::1/128"} {
+		if !m.Delete(mustIP(t, n)) {t.Fatalf("Delete(%s) = false", n)}
+	}
+	if m.Len() != 0 || m.v4 != nil || m.v6 != nil {t.Errorf("Prefixmap not empty after deleting every network")}
+	if _, _, found := m.Lookup(mustIP(t, "10.0.1.1/32")); found {t.Error("Lookup in empty Prefixmap found network")}
+}
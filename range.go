@@ -0,0 +1,83 @@
+package goIP
+
+import (
+	"errors"
+	"strings"
+)
+
+// Public Iprange struct
+type Iprange struct {
+	span
+	isv6 bool
+}
+
+// Private functions
+
+func newRange(first, firstof, last, lastof uint64, isv6 bool) (*Iprange, error) {
+	if !isv6 && (firstof != 0 || lastof != 0 || first > 0xffffffff || last > 0xffffffff) {
+		return nil, errors.New("IP out of IPv4 address space")
+	}
+	if cmp128(first, firstof, last, lastof) > 0 {return nil, errors.New("First IP of range greater than last")}
+	newrange := Iprange{
+		span: span{first: first, firstof: firstof, last: last, lastof: lastof},
+		isv6: isv6}
+	return &newrange, nil
+}
+
+// Public functions
+
+// Initialize new instance of Iprange from "<first ip>-<last ip>" string, rejecting prefix lengths and zones
+func NewRange(r string) (*Iprange, error) {
+	if strings.Count(r, "-") != 1 {return nil, errors.New("Range formatted incorrectly")}
+	if strings.Contains(r, "/") {return nil, errors.New("Range cannot contain prefix lengths")}
+	if strings.Contains(r, "%") {return nil, errors.New("Range cannot contain zones")}
+	tokens := strings.Split(r, "-")
+	first, err := NewIP(strings.TrimSpace(tokens[0]))
+	if err != nil {return nil, err}
+	last, err := NewIP(strings.TrimSpace(tokens[1]))
+	if err != nil {return nil, err}
+	return NewRangeIP(first, last)
+}
+
+// Initialize new instance of Iprange from first and last instances of Ipinfo
+func NewRangeIP(first, last *Ipinfo) (*Iprange, error) {
+	if first.isv6 != last.isv6 {return nil, errors.New("IP versions of range do not match")}
+	return newRange(first.ip, first.ipof, last.ip, last.ipof, first.isv6)
+}
+
+// Convert 2 uint64, lower and upper bits, of first and last IP of a range to minimal list of networks
+func Rangetoprefixes(first, firstof, last, lastof uint64, isv6 bool) ([]*Ipinfo, error) {
+	r, err := newRange(first, firstof, last, lastof, isv6)
+	if err != nil {return nil, err}
+	return r.Prefixes(), nil
+}
+
+// Return 2 uint64, lower and upper bits, of first IP
+func (r Iprange) Firstint() (uint64, uint64) {
+	return r.first, r.firstof
+}
+
+// Return first IP string
+func (r Iprange) First() (string) {
+	return Iptostr(r.first, r.firstof, r.isv6)
+}
+
+// Return 2 uint64, lower and upper bits, of last IP
+func (r Iprange) Lastint() (uint64, uint64) {
+	return r.last, r.lastof
+}
+
+// Return last IP string
+func (r Iprange) Last() (string) {
+	return Iptostr(r.last, r.lastof, r.isv6)
+}
+
+// Return bool of IPv6 or not
+func (r Iprange) Isv6() (bool) {
+	return r.isv6
+}
+
+// Return minimal list of networks covering range
+func (r Iprange) Prefixes() ([]*Ipinfo) {
+	return spanPrefixes(r.span, r.isv6)
+}
@@ -0,0 +1,96 @@
+package goIP
+
+import (
+	"slices"
+	"testing"
+)
+
+// Tests
+
+func TestNewRange(t *testing.T) {
+	tests := []struct {
+		in string
+		first, last string
+		ok bool
+	}{
+		{"10.0.0.1-10.0.0.6", "10.0.0.1", "10.0.0.6", true},
+		{" 10.0.0.1 - 10.0.0.1 ", "10.0.0.1", "10.0.0.1", true},
+		{"2001:db8::-2001:db8::ff", "2001:db8::", "2001:db8::ff", true},
+		{"10.0.0.6-10.0.0.1", "", "", false},
+		{"10.0.0.1-2001:db8::1", "", "", false},
+		{"10.0.0.1", "", "", false},
+		{"10.0.0.1-10.0.0.2-10.0.0.3", "", "", false},
+		{"10.0.0.0/8-10.0.0.3/30", "", "", false},
+		{"10.0.0.0-10.0.0.3/30", "", "", false},
+		{"fe80::1%eth0-fe80::2%eth0", "", "", false},
+		{"10.0.0.x-10.0.0.3", "", "", false},
+	}
+	for _, test := range tests {
+		r, err := NewRange(test.in)
+		if !test.ok {
+			if err == nil {t.Errorf("NewRange(%q) = %s-%s, want error", test.in, r.First(), r.Last())}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NewRange(%q): %v", test.in, err)
+			continue
+		}
+		if r.First() != test.first || r.Last() != test.last {
+			t.Errorf("NewRange(%q) = %s-%s, want %s-%s", test.in, r.First(), r.Last(), test.first, test.last)
+		}
+	}
+}
+
+func TestRangetoprefixes(t *testing.T) {
+	tests := []struct {
+		first, last string
+		want []string
+	}{
+		{"10.0.0.0", "10.0.0.0", []string{"10.0.0.0/32"}},
+		{"10.0.0.1", "10.0.0.6", []string{"10.0.0.1/32", "10.0.0.2/31", "10.0.0.4/31", "10.0.0.6/32"}},
+		{"10.0.0.0", "10.0.1.255", []string{"10.0.0.0/23"}},
+		{"0.0.0.0", "255.255.255.255", []string{"0.0.0.0/0"}},
+		{"255.255.255.254", "255.255.255.255", []string{"255.255.255.254/31"}},
+		{"::", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", []string{"::/0"}},
+		{"::", "::ffff:ffff:ffff:ffff", []string{"::/64"}},
+		{"2001:db8::ffff:ffff:ffff:fffe", "2001:db8:0:1::1", []string{"2001:db8::ffff:ffff:ffff:fffe/127", "2001:db8:0:1::/127"}},
+		{"2001:db8:0:0:8000::", "2001:db8:0:1:7fff:ffff:ffff:ffff", []string{"2001:db8:0:0:8000::/65", "2001:db8:0:1::/65"}},
+		{"2001:db8::ffff:ffff:ffff:ffff", "2001:db8:0:2::", []string{"2001:db8::ffff:ffff:ffff:ffff/128", "2001:db8:0:1::/64", "2001:db8:0:2::/128"}},
+	}
+	for _, test := range tests {
+		first, last := mustIP(t, test.first), mustIP(t, test.last)
+		got, err := Rangetoprefixes(first.ip, first.ipof, last.ip, last.ipof, first.isv6)
+		if err != nil {
+			t.Errorf("Rangetoprefixes(%s, %s): %v", test.first, test.last, err)
+			continue
+		}
+		if !slices.Equal(netStrings(got), test.want) {
+			t.Errorf("Rangetoprefixes(%s, %s) = %v, want %v", test.first, test.last, netStrings(got), test.want)
+		}
+	}
+	if _, err := Rangetoprefixes(0, 1, 0, 1, false); err == nil {t.Error("Rangetoprefixes accepted IPv4 range with upper bits set")}
+	if _, err := Rangetoprefixes(2, 0, 1, 0, true); err == nil {t.Error("Rangetoprefixes accepted reversed range")}
+}
+
+func TestRangetoprefixesAddresses(t *testing.T) {
+	// Ranges within 32 IPs straddling the boundary between upper and lower bits
+	base, baseof := uint64(0xfffffffffffffff0), uint64(0x20010db800000000)
+	for start := uint64(0); start < 32; start++ {
+		for end := start; end < 32; end++ {
+			first, firstof, _ := add128(base, baseof, start, 0)
+			last, lastof, _ := add128(base, baseof, end, 0)
+			got, err := Rangetoprefixes(first, firstof, last, lastof, true)
+			if err != nil {t.Fatalf("Rangetoprefixes over %d to %d: %v", start, end, err)}
+			ip, ipof := first, firstof
+			for _, n := range got {
+				for host := range n.Hosts() {
+					if host.ip != ip || host.ipof != ipof {t.Fatalf("Rangetoprefixes over %d to %d = %v, not contiguous", start, end, netStrings(got))}
+					ip, ipof, _ = add128(ip, ipof, 1, 0)
+				}
+			}
+			if next, nextof, _ := add128(last, lastof, 1, 0); ip != next || ipof != nextof {
+				t.Fatalf("Rangetoprefixes over %d to %d = %v, not covering range", start, end, netStrings(got))
+			}
+		}
+	}
+}
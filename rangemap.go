@@ -0,0 +1,85 @@
+package goIP
+
+import (
+	"errors"
+	"iter"
+	"slices"
+)
+
+// Private rmentry struct storing a range and its value
+type rmentry[V any] struct {
+	span
+	value V
+}
+
+// Public Rangemap struct storing values by non-overlapping range for point lookups, zones being ignored
+type Rangemap[V any] struct {
+	v4 []rmentry[V]
+	v6 []rmentry[V]
+}
+
+// Private functions
+
+func (m *Rangemap[V]) entries(isv6 bool) (*[]rmentry[V]) {
+	if isv6 {return &m.v6}
+	return &m.v4
+}
+
+// Return index of first entry whose last IP is not below given IP
+func searchEntries[V any](entries []rmentry[V], ip, ipof uint64) (int) {
+	n, _ := slices.BinarySearchFunc(entries, span{last: ip, lastof: ipof}, func(e rmentry[V], target span) (int) {
+		return cmp128(e.last, e.lastof, target.last, target.lastof)
+	})
+	return n
+}
+
+// Public functions
+
+// Insert value for range and return error if range overlaps an existing range
+func (m *Rangemap[V]) Insert(r *Iprange, value V) (error) {
+	entries := m.entries(r.isv6)
+	n := searchEntries(*entries, r.first, r.firstof)
+	if n < len(*entries) && cmp128((*entries)[n].first, (*entries)[n].firstof, r.last, r.lastof) <= 0 {
+		return errors.New("Range overlaps existing range")
+	}
+	*entries = slices.Insert(*entries, n, rmentry[V]{span: r.span, value: value})
+	return nil
+}
+
+// Delete value for exact range and return bool of value found or not
+func (m *Rangemap[V]) Delete(r *Iprange) (bool) {
+	entries := m.entries(r.isv6)
+	n := searchEntries(*entries, r.first, r.firstof)
+	if n == len(*entries) || (*entries)[n].span != r.span {return false}
+	*entries = slices.Delete(*entries, n, n+1)
+	return true
+}
+
+// Return range containing IP, its value, and bool of match found or not
+func (m *Rangemap[V]) Get(ip *Ipinfo) (*Iprange, V, bool) {
+	entries := *m.entries(ip.isv6)
+	n := searchEntries(entries, ip.ip, ip.ipof)
+	if n == len(entries) || cmp128(entries[n].first, entries[n].firstof, ip.ip, ip.ipof) > 0 {
+		var zero V
+		return nil, zero, false
+	}
+	r := Iprange{span: entries[n].span, isv6: ip.isv6}
+	return &r, entries[n].value, true
+}
+
+// Return iterator over every range and its value, IPv4 before IPv6 and sorted by IP
+func (m *Rangemap[V]) All() iter.Seq2[*Iprange, V] {
+	return func(yield func(*Iprange, V) bool) {
+		for _, e := range m.v4 {
+			if !yield(&Iprange{span: e.span, isv6: false}, e.value) {return}
+		}
+		for _, e := range m.v6 {
+			if !yield(&Iprange{span: e.span, isv6: true}, e.value) {return}
+		}
+	}
+}
+
+// Return number of ranges stored
+func (m *Rangemap[V]) Len() (int) {
+	return len(m.v4)+len(m.v6)
+}
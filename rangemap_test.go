@@ -0,0 +1,95 @@
+package goIP
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Tests
+
+func TestRangemapRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	bases := []struct {
+		ip, ipof uint64
+		isv6 bool
+	}{
+		{0x0a000000, 0, false},
+		{0xffffffffffffffe0, 0x20010db800000000, true},
+	}
+	for _, base := range bases {
+		at := func(k int) (uint64, uint64) {
+			ip, ipof, _ := add128(base.ip, base.ipof, uint64(k), 0)
+			return ip, ipof
+		}
+		for round := 0; round < 50; round++ {
+			var m Rangemap[int]
+			// Owner of each of 64 IPs, the first IP of its range, or -1
+			var owner, values [64]int
+			for k := range owner {owner[k] = -1}
+			count := 0
+			for op := 0; op < 200; op++ {
+				start := rng.Intn(64)
+				end := start+rng.Intn(min(64-start, 8))
+				first, firstof := at(start)
+				last, lastof := at(end)
+				r, err := newRange(first, firstof, last, lastof, base.isv6)
+				if err != nil {t.Fatal(err)}
+				if rng.Intn(3) == 0 {
+					want := owner[start] == start && (end == 63 || owner[end+1] != start) && owner[end] == start
+					if got := m.Delete(r); got != want {t.Fatalf("Delete(%s-%s) = %v, want %v", r.First(), r.Last(), got, want)}
+					if want {
+						for k := start; k <= end; k++ {owner[k] = -1}
+						count--
+					}
+				} else {
+					overlaps := false
+					for k := start; k <= end; k++ {overlaps = overlaps || owner[k] != -1}
+					err := m.Insert(r, op)
+					if overlaps != (err != nil) {t.Fatalf("Insert(%s-%s) error = %v, want overlap %v", r.First(), r.Last(), err, overlaps)}
+					if !overlaps {
+						for k := start; k <= end; k++ {owner[k] = start}
+						values[start] = op
+						count++
+					}
+				}
+				if m.Len() != count {t.Fatalf("Len() = %d, want %d", m.Len(), count)}
+				for k := range owner {
+					ip, ipof := at(k)
+					got, value, found := m.Get(newIP(ip, ipof, maxPrefix(base.isv6), base.isv6))
+					if found != (owner[k] != -1) {t.Fatalf("Get(%s) found = %v", Iptostr(ip, ipof, base.isv6), found)}
+					if !found {continue}
+					first, firstof := at(owner[k])
+					if value != values[owner[k]] || got.first != first || got.firstof != firstof {
+						t.Fatalf("Get(%s) = %s-%s, %d, want range from %s, %d", Iptostr(ip, ipof, base.isv6), got.First(), got.Last(), value, Iptostr(first, firstof, base.isv6), values[owner[k]])
+					}
+				}
+			}
+			var prev *Iprange
+			seen := 0
+			for r, value := range m.All() {
+				if prev != nil && cmp128(prev.last, prev.lastof, r.first, r.firstof) >= 0 {t.Fatalf("All() yielded %s-%s after %s-%s", r.First(), r.Last(), prev.First(), prev.Last())}
+				if _, want, _ := m.Get(newIP(r.first, r.firstof, 0, r.isv6)); value != want {t.Fatalf("All() yielded %s-%s with %d, want %d", r.First(), r.Last(), value, want)}
+				prev = r
+				seen++
+			}
+			if seen != count {t.Fatalf("All() yielded %d ranges, want %d", seen, count)}
+		}
+	}
+}
+
+func TestRangemapFamilies(t *testing.T) {
+	var m Rangemap[string]
+	for _, r := range []string{"2001:db8::-2001:db8::ff", "10.0.0.0-10.0.0.255", "0.0.0.0-0.0.0.0"} {
+		newrange, err := NewRange(r)
+		if err != nil {t.Fatal(err)}
+		if err := m.Insert(newrange, r); err != nil {t.Fatalf("Insert(%s): %v", r, err)}
+	}
+	var got []string
+	for _, value := range m.All() {got = append(got, value)}
+	if want := []string{"0.0.0.0-0.0.0.0", "10.0.0.0-10.0.0.255", "2001:db8::-2001:db8::ff"}; !slices.Equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+	if _, _, found := m.Get(mustIP(t, "::a00:1")); found {t.Error("Get of IPv6 found IPv4 range")}
+	if _, value, _ := m.Get(mustIP(t, "10.0.0.1")); value != "10.0.0.0-10.0.0.255" {t.Errorf("Get(10.0.0.1) = %q", value)}
+}
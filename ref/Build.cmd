@@ -27,6 +27,8 @@ if %errorlevel% == 1 (
 	cd ..
 	del go.sum
 	echo module github.com/ScriptTiger/goIP>go.mod
+	echo.>>go.mod
+	echo go 1.23>>go.mod
 )
 exit /b
 
@@ -0,0 +1,60 @@
+package goIP
+
+// Public Relation type describing how one network relates to another
+type Relation int
+
+// Relations returned by Relate
+const (
+	Reldisjoint Relation = iota
+	Relequal
+	Relcontains
+	Relwithin
+	Reladjacentbefore
+	Reladjacentafter
+)
+
+// Public functions
+
+// Return relation name
+func (r Relation) String() (string) {
+	switch r {
+	case Relequal: return "equal"
+	case Relcontains: return "contains"
+	case Relwithin: return "within"
+	case Reladjacentbefore: return "adjacent before"
+	case Reladjacentafter: return "adjacent after"
+	default: return "disjoint"
+	}
+}
+
+// Return relation of network to given network, networks of different IP versions or zones, including a missing zone, being disjoint
+func (i Ipinfo) Relate(n *Ipinfo) (Relation) {
+	if i.isv6 != n.isv6 || i.zone != n.zone {return Reldisjoint}
+	first := cmp128(i.prefix, i.prefixof, n.prefix, n.prefixof)
+	last := cmp128(i.limit, i.limitof, n.limit, n.limitof)
+	switch {
+	case first == 0 && last == 0: return Relequal
+	case first <= 0 && last >= 0: return Relcontains
+	case first >= 0 && last <= 0: return Relwithin
+	}
+	if next, nextof, carry := add128(i.limit, i.limitof, 1, 0); carry == 0 && next == n.prefix && nextof == n.prefixof {
+		return Reladjacentbefore
+	}
+	if next, nextof, carry := add128(n.limit, n.limitof, 1, 0); carry == 0 && next == i.prefix && nextof == i.prefixof {
+		return Reladjacentafter
+	}
+	return Reldisjoint
+}
+
+// Return bool of IP within network or not, IPs in other zones never being within
+func (i Ipinfo) Contains(ip *Ipinfo) (bool) {
+	if i.isv6 != ip.isv6 || i.zone != ip.zone {return false}
+	ok, _ := i.Ispeer(ip.ip, ip.ipof)
+	return ok
+}
+
+// Return bool of networks sharing any IP or not, networks in other zones never overlapping
+func (i Ipinfo) Overlaps(n *Ipinfo) (bool) {
+	if i.isv6 != n.isv6 || i.zone != n.zone {return false}
+	return cmp128(i.limit, i.limitof, n.prefix, n.prefixof) >= 0 && cmp128(n.limit, n.limitof, i.prefix, i.prefixof) >= 0
+}
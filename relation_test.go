@@ -0,0 +1,75 @@
+package goIP
+
+import (
+	"testing"
+)
+
+// Tests
+
+func TestRelate(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want Relation
+	}{
+		{"10.0.0.0/24", "10.0.0.0/24", Relequal},
+		{"10.0.0.5/24", "10.0.0.9/24", Relequal},
+		{"10.0.0.0/16", "10.0.5.0/24", Relcontains},
+		{"0.0.0.0/0", "255.255.255.255/32", Relcontains},
+		{"10.0.5.0/24", "10.0.0.0/16", Relwithin},
+		{"10.0.0.0/24", "10.0.1.0/24", Reladjacentbefore},
+		{"10.0.1.0/24", "10.0.0.0/24", Reladjacentafter},
+		{"10.0.0.0/24", "10.0.2.0/24", Reldisjoint},
+		{"10.0.0.0/24", "::a00:0/120", Reldisjoint},
+		{"2001:db8::/64", "2001:db8:0:1::/64", Reladjacentbefore},
+		{"::ffff:ffff:ffff:ffff/128", "0:0:0:1::/128", Reladjacentbefore},
+		{"0:0:0:1::/128", "::ffff:ffff:ffff:ffff/128", Reladjacentafter},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128", "::/128", Reldisjoint},
+		{"fe80::/64", "fe80::1%eth0/128", Reldisjoint},
+		{"fe80::%eth0/64", "fe80::1%eth0/128", Relcontains},
+		{"fe80::%eth0/64", "fe80::%eth1/64", Reldisjoint},
+	}
+	for _, test := range tests {
+		a, b := mustIP(t, test.a), mustIP(t, test.b)
+		if got := a.Relate(b); got != test.want {t.Errorf("%s.Relate(%s) = %s, want %s", test.a, test.b, got, test.want)}
+		overlaps := test.want == Relequal || test.want == Relcontains || test.want == Relwithin
+		if got := a.Overlaps(b); got != overlaps {t.Errorf("%s.Overlaps(%s) = %v, want %v", test.a, test.b, got, overlaps)}
+	}
+}
+
+func TestContains(t *testing.T) {
+	tests := []struct {
+		n, ip string
+		want bool
+	}{
+		{"10.0.0.0/24", "10.0.0.0", true},
+		{"10.0.0.0/24", "10.0.0.255", true},
+		{"10.0.0.0/24", "10.0.1.0", false},
+		{"10.0.0.0/24", "::a00:1", false},
+		{"2001:db8::/64", "2001:db8::ffff:ffff:ffff:ffff", true},
+		{"2001:db8::/64", "2001:db8:0:1::", false},
+		{"fe80::/64", "fe80::1%eth0", false},
+		{"fe80::%eth0/64", "fe80::1%eth0", true},
+		{"fe80::%eth0/64", "fe80::1", false},
+	}
+	for _, test := range tests {
+		if got := mustIP(t, test.n).Contains(mustIP(t, test.ip)); got != test.want {t.Errorf("%s.Contains(%s) = %v, want %v", test.n, test.ip, got, test.want)}
+	}
+}
+
+func TestZonesIgnored(t *testing.T) {
+	n, zoned, other := mustIP(t, "fe80::/64"), mustIP(t, "fe80::1%eth0"), mustIP(t, "fe80::%eth1/64")
+	if n.Contains(zoned) || n.Relate(other) != Reldisjoint {t.Error("Ipinfo treats missing zone as matching")}
+	var b Ipsetbuilder
+	b.Addprefix(n)
+	if s := b.Set(); !s.Contains(zoned) || !s.Containsprefix(other) {t.Error("Ipset does not ignore zones")}
+	var pm Prefixmap[int]
+	pm.Insert(other, 1)
+	if value, found := pm.Get(n); !found || value != 1 {t.Error("Prefixmap Get does not ignore zones")}
+	if got, _, found := pm.Lookup(zoned); !found || got.String() != "fe80::/64" {t.Errorf("Prefixmap Lookup = %v, %v", got, found)}
+	var rm Rangemap[int]
+	r, _ := NewRange("fe80::-fe80::ff")
+	rm.Insert(r, 1)
+	if _, _, found := rm.Get(zoned); !found {t.Error("Rangemap Get does not ignore zones")}
+	if got := netStrings(Aggregate([]*Ipinfo{n, other})); len(got) != 1 || got[0] != "fe80::/64" {t.Errorf("Aggregate = %v", got)}
+	if got := other.Exclude(mustIP(t, "fe80::/65")); len(got) != 1 || got[0].String() != "fe80::8000:0:0:0/65" {t.Errorf("Exclude = %v", netStrings(got))}
+}
@@ -0,0 +1,91 @@
+package goIP
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Private functions
+
+// Return reverse lookup name of the first units octets of IPv4 or nibbles of IPv6
+func reverseName(ip, ipof uint64, units int, isv6 bool) (string) {
+	var builder strings.Builder
+	for l := units-1; l >= 0; l-- {
+		if isv6 {
+			if l < 16 {builder.WriteString(strconv.FormatUint(ipof>>(60-4*l) & 0xf, 16))
+			} else {builder.WriteString(strconv.FormatUint(ip>>(60-4*(l-16)) & 0xf, 16))}
+		} else {builder.WriteString(strconv.FormatUint(ip>>(24-8*l) & 0xff, 10))}
+		builder.WriteString(".")
+	}
+	if isv6 {builder.WriteString("ip6.arpa")
+	} else {builder.WriteString("in-addr.arpa")}
+	return builder.String()
+}
+
+// Public functions
+
+// Return reverse lookup name of IP, in-addr.arpa for IPv4 and ip6.arpa for IPv6
+func (i Ipinfo) Reversename() (string) {
+	if i.isv6 {return reverseName(i.ip, i.ipof, 32, true)}
+	return reverseName(i.ip, i.ipof, 4, false)
+}
+
+// Return reverse zone names covering network, more than one when prefix length is not on an octet boundary for IPv4 or nibble boundary for IPv6
+func (i Ipinfo) Reversezones() ([]string) {
+	step := 8
+	if i.isv6 {step = 4}
+	boundary := (i.prefixlen+step-1)/step*step
+	var zones []string
+	for subnet := range i.Subnets(boundary) {
+		zones = append(zones, reverseName(subnet.prefix, subnet.prefixof, boundary/step, i.isv6))
+	}
+	return zones
+}
+
+// Initialize new instance of Ipinfo from reverse lookup name or reverse zone name, the prefix length covering the labels given
+func FromReversename(name string) (*Ipinfo, error) {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	var (
+		labels []string
+		isv6 bool
+	)
+	switch {
+	case name == "in-addr.arpa": isv6 = false
+	case name == "ip6.arpa": isv6 = true
+	case strings.HasSuffix(name, ".in-addr.arpa"): labels = strings.Split(strings.TrimSuffix(name, ".in-addr.arpa"), ".")
+	case strings.HasSuffix(name, ".ip6.arpa"):
+		labels = strings.Split(strings.TrimSuffix(name, ".ip6.arpa"), ".")
+		isv6 = true
+	default: return nil, errors.New("Name not in in-addr.arpa or ip6.arpa")
+	}
+	var (
+		ip, ipof uint64
+		step, maxunits int
+	)
+	if isv6 {step, maxunits = 4, 32
+	} else {step, maxunits = 8, 4}
+	if len(labels) > maxunits {return nil, errors.New("Too many labels in reverse name")}
+	for l, label := range labels {
+		// Labels run from least to most significant
+		unit := len(labels)-1-l
+		var (
+			value uint64
+			err error
+		)
+		if isv6 {
+			if len(label) != 1 {return nil, errors.New("Label "+strconv.Quote(label)+" not a single nibble")}
+			value, err = strconv.ParseUint(label, 16, 4)
+		} else {
+			if len(label) > 1 && label[0] == '0' {return nil, errors.New("Label "+strconv.Quote(label)+" has leading zero")}
+			value, err = strconv.ParseUint(label, 10, 8)
+		}
+		if err != nil {return nil, errors.New("Label "+strconv.Quote(label)+" malformed")}
+		switch {
+		case !isv6: ip |= value<<(24-8*unit)
+		case unit < 16: ipof |= value<<(60-4*unit)
+		default: ip |= value<<(60-4*(unit-16))
+		}
+	}
+	return newIP(ip, ipof, len(labels)*step, isv6), nil
+}
@@ -0,0 +1,91 @@
+package goIP
+
+import (
+	"testing"
+)
+
+// Tests
+
+func TestReversename(t *testing.T) {
+	tests := []struct {
+		in string
+		want string
+	}{
+		{"192.0.2.1", "1.2.0.192.in-addr.arpa"},
+		{"0.0.0.0", "0.0.0.0.in-addr.arpa"},
+		{"2001:db8::1", "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"},
+		{"fe80::1%eth0", "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.e.f.ip6.arpa"},
+	}
+	for _, test := range tests {
+		if got := mustIP(t, test.in).Reversename(); got != test.want {t.Errorf("%s Reversename() = %s, want %s", test.in, got, test.want)}
+	}
+}
+
+func TestReversenameRoundtrip(t *testing.T) {
+	for _, in := range []string{"192.0.2.1", "10.0.0.0", "255.255.255.255", "2001:db8::1", "::", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", "::ffff:ffff:ffff:ffff", "0:0:0:1::"} {
+		ip := mustIP(t, in)
+		got, err := FromReversename(ip.Reversename())
+		if err != nil || got.Ip() != ip.Ip() || got.Prefixlen() != maxPrefix(ip.isv6) {t.Errorf("FromReversename(%s Reversename()) = %v, %v", in, got, err)}
+		if got, err := FromReversename(ip.Reversename()+"."); err != nil || got.Ip() != ip.Ip() {t.Errorf("FromReversename with trailing dot of %s = %v, %v", in, got, err)}
+	}
+}
+
+func TestReversezones(t *testing.T) {
+	tests := []struct {
+		in string
+		count int
+		first, last string
+	}{
+		{"10.0.0.0/8", 1, "10.in-addr.arpa", "10.in-addr.arpa"},
+		{"192.0.2.0/24", 1, "2.0.192.in-addr.arpa", "2.0.192.in-addr.arpa"},
+		{"10.1.0.0/20", 16, "0.1.10.in-addr.arpa", "15.1.10.in-addr.arpa"},
+		{"10.1.2.0/23", 2, "2.1.10.in-addr.arpa", "3.1.10.in-addr.arpa"},
+		{"10.1.2.4/30", 4, "4.2.1.10.in-addr.arpa", "7.2.1.10.in-addr.arpa"},
+		{"0.0.0.0/0", 1, "in-addr.arpa", "in-addr.arpa"},
+		{"2001:db8::/32", 1, "8.b.d.0.1.0.0.2.ip6.arpa", "8.b.d.0.1.0.0.2.ip6.arpa"},
+		{"2001:db8::/30", 4, "8.b.d.0.1.0.0.2.ip6.arpa", "b.b.d.0.1.0.0.2.ip6.arpa"},
+		{"2001:db8::/127", 2, "0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa"},
+	}
+	for _, test := range tests {
+		zones := mustIP(t, test.in).Reversezones()
+		if len(zones) != test.count || zones[0] != test.first || zones[len(zones)-1] != test.last {
+			t.Errorf("%s Reversezones() = %v, want %d from %s to %s", test.in, zones, test.count, test.first, test.last)
+		}
+		for _, zone := range zones {
+			n, err := FromReversename(zone)
+			if err != nil || !mustIP(t, test.in).Overlaps(n) {t.Errorf("FromReversename(%s) = %v, %v, outside %s", zone, n, err, test.in)}
+		}
+	}
+}
+
+func TestFromReversename(t *testing.T) {
+	tests := []struct {
+		in string
+		want string
+	}{
+		{"1.2.0.192.in-addr.arpa", "192.0.2.1/32"},
+		{"2.0.192.IN-ADDR.ARPA.", "192.0.2.0/24"},
+		{"10.in-addr.arpa", "10.0.0.0/8"},
+		{"in-addr.arpa", "0.0.0.0/0"},
+		{"8.b.d.0.1.0.0.2.ip6.arpa", "2001:db8::/32"},
+		{"B.D.0.1.0.0.2.ip6.arpa", "2001:db0::/28"},
+		{"ip6.arpa", "::/0"},
+		{"256.in-addr.arpa", ""},
+		{"01.in-addr.arpa", ""},
+		{"-1.in-addr.arpa", ""},
+		{"1.2.3.4.5.in-addr.arpa", ""},
+		{"1..2.in-addr.arpa", ""},
+		{".in-addr.arpa", ""},
+		{"10.ip6.arpa", ""},
+		{"g.ip6.arpa", ""},
+		{"0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.ip6.arpa", ""},
+		{"1.2.0.192.example.com", ""},
+		{"arpa", ""},
+	}
+	for _, test := range tests {
+		got, err := FromReversename(test.in)
+		if test.want == "" {
+			if err == nil {t.Errorf("FromReversename(%q) = %s, want error", test.in, got)}
+		} else if err != nil || got.String() != test.want {t.Errorf("FromReversename(%q) = %v, %v, want %s", test.in, got, err, test.want)}
+	}
+}
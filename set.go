@@ -0,0 +1,168 @@
+package goIP
+
+import (
+	"slices"
+)
+
+// Public Ipset struct, immutable once built and safe for concurrent reads, zones being ignored
+type Ipset struct {
+	v4 []span
+	v6 []span
+}
+
+// Public Ipsetbuilder struct to add and remove IPs before building an Ipset
+type Ipsetbuilder struct {
+	v4 []span
+	v6 []span
+}
+
+// Private functions
+
+func fullSpan(isv6 bool) ([]span) {
+	if isv6 {return []span{{first: 0, firstof: 0, last: 0xffffffffffffffff, lastof: 0xffffffffffffffff}}}
+	return []span{{first: 0, firstof: 0, last: 0xffffffff, lastof: 0}}
+}
+
+func containsSpan(spans []span, ip, ipof uint64) (bool) {
+	_, found := slices.BinarySearchFunc(spans, span{first: ip, firstof: ipof}, func(s, target span) (int) {
+		if cmp128(s.last, s.lastof, target.first, target.firstof) < 0 {return -1}
+		if cmp128(s.first, s.firstof, target.first, target.firstof) > 0 {return 1}
+		return 0
+	})
+	return found
+}
+
+func ipSpan(ip *Ipinfo) (span) {
+	return span{first: ip.ip, firstof: ip.ipof, last: ip.ip, lastof: ip.ipof}
+}
+
+func prefixSpan(n *Ipinfo) (span) {
+	return span{first: n.prefix, firstof: n.prefixof, last: n.limit, lastof: n.limitof}
+}
+
+func (b *Ipsetbuilder) add(s span, isv6 bool) {
+	if isv6 {b.v6 = append(b.v6, s)
+	} else {b.v4 = append(b.v4, s)}
+}
+
+func (b *Ipsetbuilder) remove(s span, isv6 bool) {
+	if isv6 {b.v6 = subtractSpans(mergeSpans(b.v6), []span{s})
+	} else {b.v4 = subtractSpans(mergeSpans(b.v4), []span{s})}
+}
+
+func spansPrefixes(spans []span, isv6 bool) ([]*Ipinfo) {
+	var prefixes []*Ipinfo
+	for _, s := range spans {prefixes = append(prefixes, spanPrefixes(s, isv6)...)}
+	return prefixes
+}
+
+// Public functions
+
+// Add single IP to builder
+func (b *Ipsetbuilder) Add(ip *Ipinfo) {
+	b.add(ipSpan(ip), ip.isv6)
+}
+
+// Add network to builder
+func (b *Ipsetbuilder) Addprefix(n *Ipinfo) {
+	b.add(prefixSpan(n), n.isv6)
+}
+
+// Add range to builder
+func (b *Ipsetbuilder) Addrange(r *Iprange) {
+	b.add(r.span, r.isv6)
+}
+
+// Add every IP of set to builder
+func (b *Ipsetbuilder) Addset(s *Ipset) {
+	b.v4 = append(b.v4, s.v4...)
+	b.v6 = append(b.v6, s.v6...)
+}
+
+// Remove single IP from builder
+func (b *Ipsetbuilder) Remove(ip *Ipinfo) {
+	b.remove(ipSpan(ip), ip.isv6)
+}
+
+// Remove network from builder
+func (b *Ipsetbuilder) Removeprefix(n *Ipinfo) {
+	b.remove(prefixSpan(n), n.isv6)
+}
+
+// Remove range from builder
+func (b *Ipsetbuilder) Removerange(r *Iprange) {
+	b.remove(r.span, r.isv6)
+}
+
+// Remove every IP of set from builder
+func (b *Ipsetbuilder) Removeset(s *Ipset) {
+	b.v4 = subtractSpans(mergeSpans(b.v4), s.v4)
+	b.v6 = subtractSpans(mergeSpans(b.v6), s.v6)
+}
+
+// Build new instance of Ipset from builder, leaving builder usable
+func (b *Ipsetbuilder) Set() (*Ipset) {
+	b.v4 = mergeSpans(b.v4)
+	b.v6 = mergeSpans(b.v6)
+	newset := Ipset{v4: slices.Clone(b.v4), v6: slices.Clone(b.v6)}
+	return &newset
+}
+
+// Return new instance of Ipset with IPs in either set
+func (s *Ipset) Union(o *Ipset) (*Ipset) {
+	var b Ipsetbuilder
+	b.Addset(s)
+	b.Addset(o)
+	return b.Set()
+}
+
+// Return new instance of Ipset with IPs in both sets
+func (s *Ipset) Intersect(o *Ipset) (*Ipset) {
+	newset := Ipset{
+		v4: subtractSpans(s.v4, subtractSpans(s.v4, o.v4)),
+		v6: subtractSpans(s.v6, subtractSpans(s.v6, o.v6))}
+	return &newset
+}
+
+// Return new instance of Ipset with IPs in set but not in other set
+func (s *Ipset) Difference(o *Ipset) (*Ipset) {
+	newset := Ipset{v4: subtractSpans(s.v4, o.v4), v6: subtractSpans(s.v6, o.v6)}
+	return &newset
+}
+
+// Return new instance of Ipset with every IPv4 and IPv6 IP not in set
+func (s *Ipset) Complement() (*Ipset) {
+	newset := Ipset{v4: subtractSpans(fullSpan(false), s.v4), v6: subtractSpans(fullSpan(true), s.v6)}
+	return &newset
+}
+
+// Return bool of IP in set or not
+func (s *Ipset) Contains(ip *Ipinfo) (bool) {
+	if ip.isv6 {return containsSpan(s.v6, ip.ip, ip.ipof)}
+	return containsSpan(s.v4, ip.ip, ip.ipof)
+}
+
+// Return bool of entire network in set or not
+func (s *Ipset) Containsprefix(n *Ipinfo) (bool) {
+	spans := s.v4
+	if n.isv6 {spans = s.v6}
+	return len(subtractSpans([]span{prefixSpan(n)}, spans)) == 0
+}
+
+// Return bool of set containing no IPs or not
+func (s *Ipset) Isempty() (bool) {
+	return len(s.v4) == 0 && len(s.v6) == 0
+}
+
+// Return minimal sorted list of networks covering set, IPv4 before IPv6
+func (s *Ipset) Prefixes() ([]*Ipinfo) {
+	return append(spansPrefixes(s.v4, false), spansPrefixes(s.v6, true)...)
+}
+
+// Return sorted list of ranges covering set, IPv4 before IPv6
+func (s *Ipset) Ranges() ([]*Iprange) {
+	var ranges []*Iprange
+	for _, r := range s.v4 {ranges = append(ranges, &Iprange{span: r, isv6: false})}
+	for _, r := range s.v6 {ranges = append(ranges, &Iprange{span: r, isv6: true})}
+	return ranges
+}
@@ -0,0 +1,132 @@
+package goIP
+
+import (
+	"slices"
+	"testing"
+)
+
+// Private functions
+
+func buildSet(t *testing.T, nets ...string) (*Ipset) {
+	t.Helper()
+	var b Ipsetbuilder
+	for _, n := range mustIPs(t, nets...) {b.Addprefix(n)}
+	return b.Set()
+}
+
+// Check every IP of a small IPv4 and IPv6 universe against expected membership
+func checkSet(t *testing.T, name string, s *Ipset, want func(*Ipinfo) bool) {
+	t.Helper()
+	for _, universe := range mustIPs(t, "10.0.0.0/26", "2001:db8::/122") {
+		for ip := range universe.Hosts() {
+			if s.Contains(ip) != want(ip) {t.Errorf("%s: Contains(%s) = %v", name, ip.Ip(), s.Contains(ip))}
+		}
+	}
+}
+
+// Tests
+
+func TestIpsetOperations(t *testing.T) {
+	tests := []struct {
+		a, b []string
+		union, intersect, difference []string
+	}{
+		{nil, nil, nil, nil, nil},
+		{[]string{"10.0.0.0/27"}, nil, []string{"10.0.0.0/27"}, nil, []string{"10.0.0.0/27"}},
+		{nil, []string{"10.0.0.0/27"}, []string{"10.0.0.0/27"}, nil, nil},
+		{[]string{"10.0.0.0/27"}, []string{"10.0.0.32/27"}, []string{"10.0.0.0/26"}, nil, []string{"10.0.0.0/27"}},
+		{[]string{"10.0.0.0/26"}, []string{"10.0.0.16/28"},
+			[]string{"10.0.0.0/26"}, []string{"10.0.0.16/28"}, []string{"10.0.0.0/28", "10.0.0.32/27"}},
+		{[]string{"10.0.0.0/28", "2001:db8::/123"}, []string{"10.0.0.8/29", "10.0.0.16/29", "2001:db8::10/124", "2001:db8::20/124"},
+			[]string{"10.0.0.0/28", "10.0.0.16/29", "2001:db8::/123", "2001:db8::20/124"},
+			[]string{"10.0.0.8/29", "2001:db8::10/124"},
+			[]string{"10.0.0.0/29", "2001:db8::/124"}},
+		{[]string{"10.0.0.1/32", "2001:db8::1/128"}, []string{"2001:db8::/122"},
+			[]string{"10.0.0.1/32", "2001:db8::/122"}, []string{"2001:db8::1/128"}, []string{"10.0.0.1/32"}},
+	}
+	for _, test := range tests {
+		a, b := buildSet(t, test.a...), buildSet(t, test.b...)
+		union, intersect, difference := a.Union(b), a.Intersect(b), a.Difference(b)
+		if got := netStrings(union.Prefixes()); !slices.Equal(got, test.union) {t.Errorf("%v Union %v = %v, want %v", test.a, test.b, got, test.union)}
+		if got := netStrings(intersect.Prefixes()); !slices.Equal(got, test.intersect) {t.Errorf("%v Intersect %v = %v, want %v", test.a, test.b, got, test.intersect)}
+		if got := netStrings(difference.Prefixes()); !slices.Equal(got, test.difference) {t.Errorf("%v Difference %v = %v, want %v", test.a, test.b, got, test.difference)}
+		checkSet(t, "Union", union, func(ip *Ipinfo) bool {return a.Contains(ip) || b.Contains(ip)})
+		checkSet(t, "Intersect", intersect, func(ip *Ipinfo) bool {return a.Contains(ip) && b.Contains(ip)})
+		checkSet(t, "Difference", difference, func(ip *Ipinfo) bool {return a.Contains(ip) && !b.Contains(ip)})
+		if intersect.Isempty() != (len(test.intersect) == 0) {t.Errorf("%v Intersect %v Isempty() = %v", test.a, test.b, intersect.Isempty())}
+	}
+}
+
+func TestIpsetComplement(t *testing.T) {
+	empty := buildSet(t)
+	if got := netStrings(empty.Complement().Prefixes()); !slices.Equal(got, []string{"0.0.0.0/0", "::/0"}) {
+		t.Errorf("Complement of empty set = %v", got)
+	}
+	if !empty.Complement().Complement().Isempty() {t.Error("Complement of full set not empty")}
+	s := buildSet(t, "10.0.0.0/27", "10.0.0.48/28", "2001:db8::/121")
+	complement := s.Complement()
+	checkSet(t, "Complement", complement, func(ip *Ipinfo) bool {return !s.Contains(ip)})
+	if got := netStrings(complement.Complement().Prefixes()); !slices.Equal(got, netStrings(s.Prefixes())) {
+		t.Errorf("Complement of complement = %v, want %v", got, netStrings(s.Prefixes()))
+	}
+	if !s.Union(complement).Complement().Isempty() {t.Error("Union with complement not full")}
+	if !s.Intersect(complement).Isempty() {t.Error("Intersect with complement not empty")}
+	v4 := buildSet(t, "0.0.0.0/1", "128.0.0.0/1")
+	if got := netStrings(v4.Complement().Prefixes()); !slices.Equal(got, []string{"::/0"}) {
+		t.Errorf("Complement of full IPv4 set = %v, want [::/0]", got)
+	}
+}
+
+func TestIpsetRemoveprefix(t *testing.T) {
+	tests := []struct {
+		add, remove []string
+		want []string
+	}{
+		{[]string{"10.0.0.0/24"}, []string{"10.0.0.0/25"}, []string{"10.0.0.128/25"}},
+		{[]string{"10.0.0.0/24"}, []string{"10.0.0.0/8"}, nil},
+		{[]string{"10.0.0.0/30"}, []string{"10.0.0.1/32", "10.0.0.2/32"}, []string{"10.0.0.0/32", "10.0.0.3/32"}},
+		{[]string{"10.0.0.0/24", "2001:db8::/64"}, []string{"2001:db8::/65"}, []string{"10.0.0.0/24", "2001:db8:0:0:8000::/65"}},
+		{[]string{"10.0.0.0/24"}, []string{"2001:db8::/32"}, []string{"10.0.0.0/24"}},
+		{[]string{"2001:db8::/63"}, []string{"2001:db8::/65", "2001:db8:0:1:8000::/65"}, []string{"2001:db8:0:0:8000::/65", "2001:db8:0:1::/65"}},
+	}
+	for _, test := range tests {
+		var b Ipsetbuilder
+		for _, n := range mustIPs(t, test.add...) {b.Addprefix(n)}
+		for _, n := range mustIPs(t, test.remove...) {b.Removeprefix(n)}
+		if got := netStrings(b.Set().Prefixes()); !slices.Equal(got, test.want) {
+			t.Errorf("%v Removeprefix %v = %v, want %v", test.add, test.remove, got, test.want)
+		}
+	}
+	// Adding after removing uses the builder left by Removeprefix
+	var b Ipsetbuilder
+	b.Addprefix(mustIP(t, "10.0.0.0/26"))
+	b.Removeprefix(mustIP(t, "10.0.0.0/27"))
+	b.Add(mustIP(t, "10.0.0.5"))
+	s := b.Set()
+	checkSet(t, "Removeprefix", s, func(ip *Ipinfo) bool {return ip.Isv4() && (ip.ip >= 0x0a000020 || ip.ip == 0x0a000005)})
+}
+
+func TestIpsetContainsprefix(t *testing.T) {
+	s := buildSet(t, "10.0.0.0/25", "10.0.0.128/26", "2001:db8::/64", "2001:db8:0:1::/64")
+	tests := []struct {
+		n string
+		want bool
+	}{
+		{"10.0.0.0/25", true},
+		{"10.0.0.0/32", true},
+		{"10.0.0.64/26", true},
+		{"10.0.0.0/24", false},
+		{"10.0.0.64/25", true},
+		{"10.0.0.192/26", false},
+		{"0.0.0.0/0", false},
+		{"2001:db8::/63", true},
+		{"2001:db8:0:0:8000::/64", true},
+		{"2001:db8::/62", false},
+		{"::a00:0/120", false},
+	}
+	for _, test := range tests {
+		if got := s.Containsprefix(mustIP(t, test.n)); got != test.want {
+			t.Errorf("Containsprefix(%s) = %v, want %v", test.n, got, test.want)
+		}
+	}
+}
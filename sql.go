@@ -0,0 +1,80 @@
+package goIP
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+// Length of sortable key returned by Key and Netkey
+const keyLen = 18
+
+// Private functions
+
+// Parse text of an inet or cidr column, an IP without prefix length being a single host as in PostgreSQL
+func parseSQL(text string) (*Ipinfo, error) {
+	if strings.Contains(text, "/") {return NewIP(text)}
+	newip, err := NewIP(text)
+	if err != nil {return nil, err}
+	return newIP(newip.ip, newip.ipof, maxPrefix(newip.isv6), newip.isv6).withZone(newip.zone), nil
+}
+
+func newKey(ip, ipof uint64, prefixlen int, isv6 bool) ([]byte) {
+	key := make([]byte, 1, keyLen)
+	if isv6 {key[0] = 6
+	} else {key[0] = 4}
+	key = binary.BigEndian.AppendUint64(key, ipof)
+	key = binary.BigEndian.AppendUint64(key, ip)
+	return append(key, byte(prefixlen))
+}
+
+// Public functions
+
+// Implement sql.Scanner from inet or cidr style text, or a 4 or 16 byte blob of a single host,
+// 4 bytes always being a blob as no IPv4 text is that short
+func (i *Ipinfo) Scan(src any) (error) {
+	var (
+		newip *Ipinfo
+		err error
+	)
+	switch v := src.(type) {
+	case string: newip, err = parseSQL(v)
+	case []byte:
+		if len(v) == 4 {
+			newip, err = FromBytes(v, 32)
+			break
+		}
+		newip, err = parseSQL(string(v))
+		if err != nil && len(v) == 16 {newip, err = FromBytes(v, 128)}
+	case nil: return errors.New("Cannot scan NULL into Ipinfo")
+	default: return errors.New("Cannot scan non-text, non-blob value into Ipinfo")
+	}
+	if err != nil {return err}
+	*i = *newip
+	return nil
+}
+
+// Implement driver.Valuer as text in CIDR notation, accepted by inet and text columns, returning error for IPv6 with zone as inet cannot hold one
+func (i Ipinfo) Value() (driver.Value, error) {
+	if i.zone != "" {return nil, errors.New("IPv6 zone cannot be stored in inet or cidr")}
+	return i.String(), nil
+}
+
+// Return 18 byte key of IP version, upper and lower bits of IP, and prefix length, unique per inet value and
+// sorting bytewise in the same order as Compare and then by prefix length, zones being dropped
+func (i Ipinfo) Key() ([]byte) {
+	return newKey(i.ip, i.ipof, i.prefixlen, i.isv6)
+}
+
+// Return 18 byte key as Key but of prefix rather than IP, sorting bytewise in the same order as Comparenet, zones being dropped
+func (i Ipinfo) Netkey() ([]byte) {
+	return newKey(i.prefix, i.prefixof, i.prefixlen, i.isv6)
+}
+
+// Initialize new instance of Ipinfo from key returned by Key or Netkey
+func FromKey(key []byte) (*Ipinfo, error) {
+	if len(key) != keyLen {return nil, errors.New("Key must be 18 bytes")}
+	if key[0] != 4 && key[0] != 6 {return nil, errors.New("Key IP version unknown")}
+	return FromInts(binary.BigEndian.Uint64(key[9:17]), binary.BigEndian.Uint64(key[1:9]), int(key[17]), key[0] == 6)
+}
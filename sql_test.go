@@ -0,0 +1,82 @@
+package goIP
+
+import (
+	"cmp"
+	"bytes"
+	"slices"
+	"testing"
+)
+
+// Tests
+
+func TestKeyOrder(t *testing.T) {
+	nets := mustIPs(t, "10.0.0.5/8", "10.0.0.0/16", "10.0.0.0/8", "10.0.0.5/24", "10.0.0.6/24", "9.255.255.255/32", "0.0.0.0/0",
+		"2001:db8::1/32", "2001:db8::/48", "::/0", "fe80::1%eth0/64", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128")
+	for _, a := range nets {
+		for _, b := range nets {
+			want := Compare(a, b)
+			if want == 0 {want = cmp.Compare(a.prefixlen, b.prefixlen)}
+			if got := bytes.Compare(a.Key(), b.Key()); got != want {
+				t.Errorf("Key order of %s and %s = %d, want %d", a, b, got, want)
+			}
+			if got, want := bytes.Compare(a.Netkey(), b.Netkey()), Comparenet(a, b); got != want {
+				t.Errorf("Netkey order of %s and %s = %d, Comparenet = %d", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestKeyRoundtrip(t *testing.T) {
+	tests := []struct {
+		in string
+		key, netkey string
+	}{
+		{"10.0.0.5/8", "10.0.0.5/8", "10.0.0.0/8"},
+		{"0.0.0.0/0", "0.0.0.0/0", "0.0.0.0/0"},
+		{"255.255.255.255/32", "255.255.255.255/32", "255.255.255.255/32"},
+		{"2001:db8::1/32", "2001:db8::1/32", "2001:db8::/32"},
+		{"fe80::1%eth0/128", "fe80::1/128", "fe80::1/128"},
+	}
+	for _, test := range tests {
+		ip := mustIP(t, test.in)
+		for _, key := range [][]byte{ip.Key(), ip.Netkey()} {
+			if len(key) != keyLen {t.Errorf("Key of %s length %d", test.in, len(key))}
+		}
+		if got, err := FromKey(ip.Key()); err != nil || got.String() != test.key {t.Errorf("FromKey(Key(%s)) = %v, %v, want %s", test.in, got, err, test.key)}
+		if got, err := FromKey(ip.Netkey()); err != nil || got.String() != test.netkey {t.Errorf("FromKey(Netkey(%s)) = %v, %v, want %s", test.in, got, err, test.netkey)}
+	}
+	if bytes.Equal(mustIP(t, "10.0.0.5/24").Key(), mustIP(t, "10.0.0.6/24").Key()) {t.Error("Key of distinct inet values equal")}
+	for _, key := range [][]byte{nil, make([]byte, 17), slices.Repeat([]byte{5}, keyLen)} {
+		if _, err := FromKey(key); err == nil {t.Errorf("FromKey(%x) accepted", key)}
+	}
+}
+
+func TestScanValue(t *testing.T) {
+	tests := []struct {
+		src any
+		want string
+	}{
+		{"10.0.0.5", "10.0.0.5/32"},
+		{"10.0.0.5/8", "10.0.0.5/8"},
+		{[]byte("2001:db8::1"), "2001:db8::1/128"},
+		{[]byte{192, 0, 2, 1}, "192.0.2.1/32"},
+		{[]byte{58, 58, 97, 98}, "58.58.97.98/32"},
+		{[]byte("1::1"), "49.58.58.49/32"},
+		{[]byte("::1"), "::1/128"},
+		{[]byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, "2001:db8::1/128"},
+		{[]byte("2001:db8::1/128"), "2001:db8::1/128"},
+	}
+	for _, test := range tests {
+		var ip Ipinfo
+		if err := ip.Scan(test.src); err != nil || ip.String() != test.want {
+			t.Errorf("Scan(%v) = %s, %v, want %s", test.src, ip.String(), err, test.want)
+			continue
+		}
+		if value, err := ip.Value(); err != nil || value != test.want {t.Errorf("Value() of %s = %v, %v", test.want, value, err)}
+	}
+	for _, src := range []any{nil, 5, "10.0.0.x"} {
+		var ip Ipinfo
+		if err := ip.Scan(src); err == nil {t.Errorf("Scan(%v) accepted", src)}
+	}
+	if _, err := mustIP(t, "fe80::1%eth0").Value(); err == nil {t.Error("Value() of IP with zone accepted")}
+}
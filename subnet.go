@@ -0,0 +1,46 @@
+package goIP
+
+import (
+	"errors"
+	"slices"
+)
+
+// Maximum number of prefix length bits Split will descend in one call
+const maxSplit = 20
+
+// Private functions
+
+func shl128(n uint64, shift int) (uint64, uint64) {
+	if shift == 0 {return n, 0}
+	if shift >= 64 {return 0, n<<(shift-64)}
+	return n<<shift, n>>(64-shift)
+}
+
+// Public functions
+
+// Return new instance of Ipinfo for the enclosing network of given prefix length, IP set to its prefix
+func (i Ipinfo) Supernet(prefixlen int) (*Ipinfo, error) {
+	if prefixlen < 0 {return nil, errors.New("Prefix length cannot be negative")}
+	if prefixlen > i.prefixlen {return nil, errors.New("Prefix length longer than network")}
+	ip, ipof := maskBits(i.prefix, i.prefixof, prefixlen, i.isv6)
+	return newIP(ip, ipof, prefixlen, i.isv6).withZone(i.zone), nil
+}
+
+// Return new instance of Ipinfo for the subnet of given prefix length at given index within network
+func (i Ipinfo) Subnet(prefixlen int, index uint64) (*Ipinfo, error) {
+	if prefixlen > maxPrefix(i.isv6) {return nil, errors.New("Prefix length too large")}
+	if prefixlen < i.prefixlen {return nil, errors.New("Prefix length shorter than network")}
+	if prefixlen-i.prefixlen < 64 && index >= 1<<(prefixlen-i.prefixlen) {
+		return nil, errors.New("Subnet index out of network bounds")
+	}
+	ip, ipof := shl128(index, maxPrefix(i.isv6)-prefixlen)
+	return newIP(i.prefix|ip, i.prefixof|ipof, prefixlen, i.isv6).withZone(i.zone), nil
+}
+
+// Return slice of every subnet of given prefix length within network, limited to 2^20 subnets, beyond which use Subnets
+func (i Ipinfo) Split(prefixlen int) ([]*Ipinfo, error) {
+	if prefixlen > maxPrefix(i.isv6) {return nil, errors.New("Prefix length too large")}
+	if prefixlen < i.prefixlen {return nil, errors.New("Prefix length shorter than network")}
+	if prefixlen-i.prefixlen > maxSplit {return nil, errors.New("Too many subnets, use Subnets to iterate instead")}
+	return slices.Collect(i.Subnets(prefixlen)), nil
+}
@@ -0,0 +1,71 @@
+package goIP
+
+import (
+	"slices"
+	"testing"
+)
+
+// Tests
+
+func TestSupernet(t *testing.T) {
+	tests := []struct {
+		in string
+		prefixlen int
+		want string
+	}{
+		{"10.1.2.3/24", 20, "10.1.0.0/20"},
+		{"10.1.2.3/24", 24, "10.1.2.0/24"},
+		{"10.1.2.3/24", 0, "0.0.0.0/0"},
+		{"10.1.2.3/24", 25, ""},
+		{"10.1.2.3/24", -1, ""},
+		{"2001:db8:0:1::/64", 63, "2001:db8::/63"},
+		{"fe80::1%eth0/128", 64, "fe80::%eth0/64"},
+	}
+	for _, test := range tests {
+		got, err := mustIP(t, test.in).Supernet(test.prefixlen)
+		if test.want == "" {
+			if err == nil {t.Errorf("%s.Supernet(%d) = %s, want error", test.in, test.prefixlen, got)}
+		} else if err != nil || got.String() != test.want {t.Errorf("%s.Supernet(%d) = %v, %v, want %s", test.in, test.prefixlen, got, err, test.want)}
+	}
+}
+
+func TestSubnet(t *testing.T) {
+	tests := []struct {
+		in string
+		prefixlen int
+		index uint64
+		want string
+	}{
+		{"10.0.0.0/16", 24, 0, "10.0.0.0/24"},
+		{"10.0.0.0/16", 24, 255, "10.0.255.0/24"},
+		{"10.0.0.0/16", 24, 256, ""},
+		{"10.0.0.0/16", 16, 0, "10.0.0.0/16"},
+		{"10.0.0.0/16", 16, 1, ""},
+		{"10.0.0.0/16", 15, 0, ""},
+		{"10.0.0.0/16", 33, 0, ""},
+		{"0.0.0.0/0", 32, 0xffffffff, "255.255.255.255/32"},
+		{"0.0.0.0/0", 32, 0x100000000, ""},
+		{"2001:db8::/32", 64, 0xffffffff, "2001:db8:ffff:ffff::/64"},
+		{"2001:db8::/32", 64, 0x100000000, ""},
+		{"2001:db8::/48", 112, 0xffffffffffffffff, "2001:db8:0:ffff:ffff:ffff:ffff:0/112"},
+		{"::/0", 128, 0xffffffffffffffff, "::ffff:ffff:ffff:ffff/128"},
+		{"::/0", 64, 0xffffffffffffffff, "ffff:ffff:ffff:ffff::/64"},
+	}
+	for _, test := range tests {
+		got, err := mustIP(t, test.in).Subnet(test.prefixlen, test.index)
+		if test.want == "" {
+			if err == nil {t.Errorf("%s.Subnet(%d, %d) = %s, want error", test.in, test.prefixlen, test.index, got)}
+		} else if err != nil || got.String() != test.want {t.Errorf("%s.Subnet(%d, %d) = %v, %v, want %s", test.in, test.prefixlen, test.index, got, err, test.want)}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	got, err := mustIP(t, "10.0.0.7/24").Split(26)
+	if want := []string{"10.0.0.0/26", "10.0.0.64/26", "10.0.0.128/26", "10.0.0.192/26"}; err != nil || !slices.Equal(netStrings(got), want) {
+		t.Errorf("Split(26) = %v, %v, want %v", netStrings(got), err, want)
+	}
+	if got, err := mustIP(t, "10.0.0.0/8").Split(28); err != nil || len(got) != 1<<20 {t.Errorf("Split into 2^20 subnets = %d, %v", len(got), err)}
+	for _, prefixlen := range []int{7, 29, 33} {
+		if _, err := mustIP(t, "10.0.0.0/8").Split(prefixlen); err == nil {t.Errorf("10.0.0.0/8 Split(%d) accepted", prefixlen)}
+	}
+}